@@ -0,0 +1,78 @@
+package curve
+
+import (
+	"math/big"
+)
+
+// Params holds the domain parameters of a short Weierstrass curve in the form
+// y^2 = x^3 + ax + b.
+type Params struct {
+	Name string
+	P    *big.Int // Prime Modulo of the Field.
+	A    *big.Int // Part of the equation y^2 = x^3 + ax + b.
+	B    *big.Int // Part of the equation y^2 = x^3 + ax + b.
+	Gx   *big.Int // X co-ordinate of the base point (generator point).
+	Gy   *big.Int // Y co-ordinate of the base point (generator point).
+	N    *big.Int // Order of the base point.
+}
+
+// Curve is the interface for an elliptic curve used by keys and signatures.
+// Points are passed around as Jacobian co-ordinates (x, y, z), where a z of
+// 0 represents the point at infinity.
+type Curve interface {
+	// Params returns the domain parameters of the curve.
+	Params() *Params
+
+	// IsOnCurve reports whether the affine x,y co-ordinates satisfy the
+	// curve equation.
+	IsOnCurve(x, y *big.Int) bool
+
+	// DecompressY returns the y co-ordinate for the affine x co-ordinate,
+	// choosing the odd or even root according to odd.
+	DecompressY(x *big.Int, odd bool) (*big.Int, error)
+
+	// JacobianAdd adds two points in Jacobian co-ordinates.
+	JacobianAdd(x1, y1, z1, x2, y2, z2 *big.Int) (*big.Int, *big.Int, *big.Int)
+
+	// JacobianDouble doubles a point in Jacobian co-ordinates.
+	JacobianDouble(x, y, z *big.Int) (*big.Int, *big.Int, *big.Int)
+
+	// AffineFromJacobian converts a point in Jacobian co-ordinates to affine
+	// co-ordinates.
+	AffineFromJacobian(x, y, z *big.Int) (*big.Int, *big.Int)
+
+	// JacobianFromAffine converts a point in affine co-ordinates to Jacobian
+	// co-ordinates.
+	JacobianFromAffine(x, y *big.Int) (*big.Int, *big.Int, *big.Int)
+
+	// ScalarMult returns k*(Bx,By) in Jacobian co-ordinates, where k is a
+	// big-endian integer.
+	ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int, *big.Int)
+
+	// MultiScalarMult returns the sum of ks[i]*(xs[i], ys[i]) in Jacobian
+	// co-ordinates, where each k is a big-endian integer. It need not be
+	// constant time, so it must only be used with public scalars.
+	MultiScalarMult(xs, ys []*big.Int, ks [][]byte) (*big.Int, *big.Int, *big.Int)
+
+	// ScalarBaseMult returns k*G in Jacobian co-ordinates, where G is the
+	// base point and k is a big-endian integer.
+	ScalarBaseMult(k []byte) (*big.Int, *big.Int, *big.Int)
+}
+
+// ScalarInverter is implemented by curves that can invert an integer modulo
+// the order N in constant time, for use with secret nonces.
+type ScalarInverter interface {
+	ScalarInverse(k *big.Int) *big.Int
+}
+
+// VartimeScalarMulter is implemented by curves with a faster scalar
+// multiplication that is not constant time, for use with public scalars.
+type VartimeScalarMulter interface {
+	ScalarMultVartime(Bx, By *big.Int, k []byte) (*big.Int, *big.Int, *big.Int)
+}
+
+// BatchAffiner is implemented by curves that can convert many points from
+// Jacobian to affine co-ordinates with a single field inversion.
+type BatchAffiner interface {
+	BatchAffineFromJacobian(xs, ys, zs []*big.Int) ([]*big.Int, []*big.Int)
+}
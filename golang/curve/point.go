@@ -0,0 +1,228 @@
+package curve
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Point is a point on a curve in affine co-ordinates. The point at infinity
+// has no affine representation, so it is stored as (0, 0), which is not on
+// any curve with a non-zero b.
+type Point struct {
+	Curve Curve
+	X     *big.Int
+	Y     *big.Int
+}
+
+// JacobianPoint is a point on a curve in Jacobian co-ordinates, where
+// (X, Y, Z) represents the affine point (X/Z^2, Y/Z^3). A Z of 0 represents
+// the point at infinity.
+type JacobianPoint struct {
+	Curve Curve
+	X     *big.Int
+	Y     *big.Int
+	Z     *big.Int
+}
+
+// NewPoint will create a Point from affine co-ordinates, checking that they
+// are on the curve c.
+func NewPoint(c Curve, x, y *big.Int) (*Point, error) {
+	p := c.Params().P
+	if x.Sign() < 0 || y.Sign() < 0 || x.Cmp(p) >= 0 || y.Cmp(p) >= 0 {
+		return nil, errors.New("point co-ordinates are not in the field")
+	}
+
+	if !c.IsOnCurve(x, y) {
+		return nil, errors.New("point is not on the curve")
+	}
+
+	return &Point{Curve: c, X: new(big.Int).Set(x), Y: new(big.Int).Set(y)}, nil
+}
+
+// Infinity returns the point at infinity on the curve c.
+func Infinity(c Curve) *Point {
+	return &Point{Curve: c, X: new(big.Int), Y: new(big.Int)}
+}
+
+// IsInfinity reports whether p is the point at infinity.
+func (p *Point) IsInfinity() bool {
+	return p.X.Sign() == 0 && p.Y.Sign() == 0
+}
+
+// Jacobian returns p in Jacobian co-ordinates.
+func (p *Point) Jacobian() *JacobianPoint {
+	if p.IsInfinity() {
+		return &JacobianPoint{Curve: p.Curve, X: big.NewInt(1), Y: big.NewInt(1), Z: new(big.Int)}
+	}
+
+	x, y, z := p.Curve.JacobianFromAffine(p.X, p.Y)
+	return &JacobianPoint{Curve: p.Curve, X: x, Y: y, Z: z}
+}
+
+// Add returns p + q. Adding a point to its negation returns the point at
+// infinity and adding a point to itself doubles it.
+func (p *Point) Add(q *Point) *Point {
+	return p.Jacobian().Add(q.Jacobian()).Affine()
+}
+
+// Double returns 2p.
+func (p *Point) Double() *Point {
+	return p.Jacobian().Double().Affine()
+}
+
+// Negate returns -p = (x, -y).
+func (p *Point) Negate() *Point {
+	if p.IsInfinity() {
+		return Infinity(p.Curve)
+	}
+
+	y := new(big.Int).Sub(p.Curve.Params().P, p.Y)
+	y.Mod(y, p.Curve.Params().P)
+
+	return &Point{Curve: p.Curve, X: new(big.Int).Set(p.X), Y: y}
+}
+
+// Sub returns p - q.
+func (p *Point) Sub(q *Point) *Point {
+	return p.Add(q.Negate())
+}
+
+// Equal reports whether p and q are the same point. Both points must be on
+// the same curve.
+func (p *Point) Equal(q *Point) bool {
+	return p.X.Cmp(q.X) == 0 && p.Y.Cmp(q.Y) == 0
+}
+
+// IsInfinity reports whether p is the point at infinity.
+func (p *JacobianPoint) IsInfinity() bool {
+	return p.Z.Sign() == 0
+}
+
+// Affine returns p in affine co-ordinates.
+func (p *JacobianPoint) Affine() *Point {
+	if p.IsInfinity() {
+		return Infinity(p.Curve)
+	}
+
+	x, y := p.Curve.AffineFromJacobian(p.X, p.Y, p.Z)
+	return &Point{Curve: p.Curve, X: x, Y: y}
+}
+
+// BatchAffine converts the points, which must all be on the same curve, to
+// affine co-ordinates. It uses Montgomery's trick so that there is one
+// modular inversion for all of the points instead of one per point.
+func BatchAffine(points []*JacobianPoint) []*Point {
+	if len(points) == 0 {
+		return nil
+	}
+	c := points[0].Curve
+
+	xs := make([]*big.Int, len(points))
+	ys := make([]*big.Int, len(points))
+	zs := make([]*big.Int, len(points))
+	for i, p := range points {
+		xs[i], ys[i], zs[i] = p.X, p.Y, p.Z
+	}
+
+	var ax, ay []*big.Int
+	if b, ok := c.(BatchAffiner); ok {
+		ax, ay = b.BatchAffineFromJacobian(xs, ys, zs)
+	} else {
+		ax, ay = batchAffineFromJacobian(c.Params().P, xs, ys, zs)
+	}
+
+	result := make([]*Point, len(points))
+	for i := range points {
+		result[i] = &Point{Curve: c, X: ax[i], Y: ay[i]}
+	}
+
+	return result
+}
+
+// batchAffineFromJacobian is the math/big version of Montgomery's trick for
+// curves that do not implement BatchAffiner. The point at infinity is
+// returned as (0, 0).
+func batchAffineFromJacobian(p *big.Int, xs, ys, zs []*big.Int) ([]*big.Int, []*big.Int) {
+	one := big.NewInt(1)
+
+	// prefix[i] is the product of z[0] to z[i-1], skipping any z of 0.
+	prefix := make([]*big.Int, len(zs)+1)
+	prefix[0] = one
+	for i, z := range zs {
+		prefix[i+1] = new(big.Int).Set(prefix[i])
+		if z.Sign() != 0 {
+			prefix[i+1].Mul(prefix[i+1], z).Mod(prefix[i+1], p)
+		}
+	}
+
+	ax := make([]*big.Int, len(zs))
+	ay := make([]*big.Int, len(zs))
+	inv := new(big.Int).ModInverse(prefix[len(zs)], p)
+	for i := len(zs) - 1; i >= 0; i-- {
+		if zs[i].Sign() == 0 {
+			ax[i], ay[i] = new(big.Int), new(big.Int)
+			continue
+		}
+
+		zInv := new(big.Int).Mul(inv, prefix[i])
+		zInv.Mod(zInv, p)
+		inv.Mul(inv, zs[i]).Mod(inv, p)
+
+		zInv2 := new(big.Int).Mul(zInv, zInv)
+		ax[i] = new(big.Int).Mul(xs[i], zInv2)
+		ax[i].Mod(ax[i], p)
+		zInv2.Mul(zInv2, zInv)
+		ay[i] = new(big.Int).Mul(ys[i], zInv2)
+		ay[i].Mod(ay[i], p)
+	}
+
+	return ax, ay
+}
+
+// Add returns p + q.
+func (p *JacobianPoint) Add(q *JacobianPoint) *JacobianPoint {
+	x, y, z := p.Curve.JacobianAdd(p.X, p.Y, p.Z, q.X, q.Y, q.Z)
+	return &JacobianPoint{Curve: p.Curve, X: x, Y: y, Z: z}
+}
+
+// Double returns 2p.
+func (p *JacobianPoint) Double() *JacobianPoint {
+	x, y, z := p.Curve.JacobianDouble(p.X, p.Y, p.Z)
+	return &JacobianPoint{Curve: p.Curve, X: x, Y: y, Z: z}
+}
+
+// Negate returns -p = (X, -Y, Z).
+func (p *JacobianPoint) Negate() *JacobianPoint {
+	y := new(big.Int).Sub(p.Curve.Params().P, p.Y)
+	y.Mod(y, p.Curve.Params().P)
+
+	return &JacobianPoint{Curve: p.Curve, X: new(big.Int).Set(p.X), Y: y, Z: new(big.Int).Set(p.Z)}
+}
+
+// Sub returns p - q.
+func (p *JacobianPoint) Sub(q *JacobianPoint) *JacobianPoint {
+	return p.Add(q.Negate())
+}
+
+// Equal reports whether p and q represent the same point, without converting
+// to affine co-ordinates. X1*Z2^2 = X2*Z1^2 and Y1*Z2^3 = Y2*Z1^3 when the
+// points are equal. Both points must be on the same curve.
+func (p *JacobianPoint) Equal(q *JacobianPoint) bool {
+	if p.IsInfinity() || q.IsInfinity() {
+		return p.IsInfinity() == q.IsInfinity()
+	}
+
+	mod := p.Curve.Params().P
+	pz2 := new(big.Int).Mul(p.Z, p.Z)
+	qz2 := new(big.Int).Mul(q.Z, q.Z)
+
+	lhs := new(big.Int).Mul(p.X, qz2)
+	rhs := new(big.Int).Mul(q.X, pz2)
+	if lhs.Sub(lhs, rhs).Mod(lhs, mod).Sign() != 0 {
+		return false
+	}
+
+	lhs.Mul(p.Y, qz2.Mul(qz2, q.Z))
+	rhs.Mul(q.Y, pz2.Mul(pz2, p.Z))
+	return lhs.Sub(lhs, rhs).Mod(lhs, mod).Sign() == 0
+}
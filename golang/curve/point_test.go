@@ -0,0 +1,147 @@
+package curve_test
+
+import (
+	"crypto/rand"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"math/big"
+	"testing"
+)
+
+// randomPoint returns k*G for a random k along with k.
+func randomPoint(t *testing.T, c curve.Curve) (*curve.Point, *big.Int) {
+	k, err := rand.Int(rand.Reader, c.Params().N)
+	if err != nil {
+		t.Fatalf("failed to generate a scalar: %v", err)
+	}
+
+	x, y := c.AffineFromJacobian(c.ScalarBaseMult(k.Bytes()))
+	p, err := curve.NewPoint(c, x, y)
+	if err != nil {
+		t.Fatalf("failed to create a point: %v", err)
+	}
+
+	return p, k
+}
+
+// multiple returns k*G as a Point.
+func multiple(c curve.Curve, k *big.Int) *curve.Point {
+	k = new(big.Int).Mod(k, c.Params().N)
+	x, y := c.AffineFromJacobian(c.ScalarBaseMult(k.Bytes()))
+	if k.Sign() == 0 {
+		return curve.Infinity(c)
+	}
+
+	return &curve.Point{Curve: c, X: x, Y: y}
+}
+
+// TestNewPoint will test that points off the curve or outside of the field
+// are rejected.
+func TestNewPoint(t *testing.T) {
+	c := secp256k1.New()
+	params := c.Params()
+
+	if _, err := curve.NewPoint(c, params.Gx, params.Gy); err != nil {
+		t.Fatalf("failed to create the generator point: %v", err)
+	}
+
+	if _, err := curve.NewPoint(c, params.Gx, new(big.Int).Add(params.Gy, big.NewInt(1))); err == nil {
+		t.Fatalf("should have rejected a point not on the curve")
+	}
+
+	if _, err := curve.NewPoint(c, params.Gx, new(big.Int).Add(params.Gy, params.P)); err == nil {
+		t.Fatalf("should have rejected a y not in the field")
+	}
+
+	if _, err := curve.NewPoint(c, new(big.Int), new(big.Int)); err == nil {
+		t.Fatalf("should have rejected the point at infinity")
+	}
+}
+
+// TestPointArithmetic will test that Add, Double, Negate and Sub agree with
+// scalar multiplication of the generator, in affine and Jacobian
+// co-ordinates.
+func TestPointArithmetic(t *testing.T) {
+	c := secp256k1.New()
+	inf := curve.Infinity(c)
+
+	for i := 0; i < 10; i++ {
+		a, ka := randomPoint(t, c)
+		b, kb := randomPoint(t, c)
+
+		sum := multiple(c, new(big.Int).Add(ka, kb))
+		diff := multiple(c, new(big.Int).Sub(ka, kb))
+		twice := multiple(c, new(big.Int).Lsh(ka, 1))
+
+		if !a.Add(b).Equal(sum) {
+			t.Fatalf("a + b does not match (ka + kb)*G")
+		}
+		if !a.Sub(b).Equal(diff) {
+			t.Fatalf("a - b does not match (ka - kb)*G")
+		}
+		if !a.Double().Equal(twice) || !a.Add(a).Equal(twice) {
+			t.Fatalf("2a does not match (2*ka)*G")
+		}
+		if !a.Add(a.Negate()).IsInfinity() || !a.Sub(a).IsInfinity() {
+			t.Fatalf("a + -a should be the point at infinity")
+		}
+		if !a.Add(inf).Equal(a) || !inf.Add(a).Equal(a) {
+			t.Fatalf("a + infinity should be a")
+		}
+
+		ja, jb := a.Jacobian().Double(), b.Jacobian()
+		if !ja.Add(jb).Equal(multiple(c, new(big.Int).Add(new(big.Int).Lsh(ka, 1), kb)).Jacobian()) {
+			t.Fatalf("2a + b in Jacobian co-ordinates does not match")
+		}
+		if !ja.Sub(ja).IsInfinity() || !ja.Add(ja.Negate()).Affine().IsInfinity() {
+			t.Fatalf("2a - 2a in Jacobian co-ordinates should be the point at infinity")
+		}
+		if ja.Equal(jb) || !ja.Equal(twice.Jacobian()) {
+			t.Fatalf("Jacobian equality does not match affine equality")
+		}
+	}
+
+	if !inf.Double().IsInfinity() || !inf.Negate().IsInfinity() {
+		t.Fatalf("2*infinity and -infinity should be the point at infinity")
+	}
+	if !inf.Jacobian().Equal(inf.Jacobian()) || inf.Jacobian().Equal(multiple(c, big.NewInt(1)).Jacobian()) {
+		t.Fatalf("infinity should only equal itself")
+	}
+}
+
+// genericCurve hides the optional interfaces of the wrapped curve, so that
+// the math/big fallbacks are used.
+type genericCurve struct {
+	curve.Curve
+}
+
+// TestBatchAffine will test that the batch conversion matches converting each
+// point separately, with and without the curve's BatchAffiner.
+func TestBatchAffine(t *testing.T) {
+	s := secp256k1.New()
+
+	for _, c := range []curve.Curve{s, genericCurve{s}} {
+		var points []*curve.JacobianPoint
+		for i := 0; i < 10; i++ {
+			p, _ := randomPoint(t, c)
+			points = append(points, p.Jacobian().Double())
+		}
+		points = append(points, curve.Infinity(c).Jacobian())
+		points = append(points, points[3].Add(points[5]))
+
+		received := curve.BatchAffine(points)
+		for i, p := range points {
+			if !p.Affine().Equal(received[i]) {
+				t.Fatalf("point %v, expected: (%v, %v), received: (%v, %v)",
+					i, p.Affine().X, p.Affine().Y, received[i].X, received[i].Y)
+			}
+		}
+		if !received[10].IsInfinity() {
+			t.Fatalf("expected the point at infinity")
+		}
+	}
+
+	if curve.BatchAffine(nil) != nil {
+		t.Fatalf("expected no points")
+	}
+}
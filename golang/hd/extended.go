@@ -0,0 +1,324 @@
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"github.com/ccdle12/bitcoin-review/golang/utils"
+)
+
+// HardenedKeyStart is the first hardened child index. Hardened children can
+// only be derived from a private extended key.
+const HardenedKeyStart uint32 = 0x80000000
+
+const (
+	// serializedKeyLen is the length of a serialized extended key before
+	// the checksum: version, depth, parent fingerprint, child number, chain
+	// code and key.
+	serializedKeyLen = 4 + 1 + 4 + 4 + 32 + 33
+
+	// minSeedLen and maxSeedLen are the seed lengths in bytes allowed by
+	// BIP 32, 128 to 512 bits.
+	minSeedLen = 16
+	maxSeedLen = 64
+)
+
+// masterKey is the HMAC-SHA512 key used to derive a master key from a seed.
+var masterKey = []byte("Bitcoin seed")
+
+// Version is the four byte prefix of a serialized extended key, which
+// determines its network and whether it is private or public. The SLIP 132
+// versions also determine the address type.
+type Version [4]byte
+
+var (
+	MainnetPrivate = Version{0x04, 0x88, 0xad, 0xe4} // xprv
+	MainnetPublic  = Version{0x04, 0x88, 0xb2, 0x1e} // xpub
+	TestnetPrivate = Version{0x04, 0x35, 0x83, 0x94} // tprv
+	TestnetPublic  = Version{0x04, 0x35, 0x87, 0xcf} // tpub
+)
+
+var (
+	ErrInvalidChild       = errors.New("derived child key is invalid, the next index should be used")
+	ErrHardenedFromPublic = errors.New("cannot derive a hardened child from a public extended key")
+	ErrMaxDepth           = errors.New("cannot derive a child beyond a depth of 255")
+	ErrInvalidMasterKey   = errors.New("seed produced an invalid master key, a different seed should be used")
+)
+
+// ExtendedKey is a BIP 32 extended private or public key, a key along with a
+// chain code that is used to derive child keys.
+type ExtendedKey struct {
+	curve             curve.Curve
+	version           Version
+	depth             uint8
+	parentFingerprint [4]byte
+	childNumber       uint32
+	chainCode         []byte
+	privateKey        *keys.PrivateKey // nil for a public extended key.
+	publicKey         *keys.PublicKey
+}
+
+// NewMaster will create a master extended private key on the curve c from a
+// seed of 16 to 64 bytes. version is the private version to serialize the key
+// with, such as MainnetPrivate.
+func NewMaster(c curve.Curve, seed []byte, version Version) (*ExtendedKey, error) {
+	if len(seed) < minSeedLen || len(seed) > maxSeedLen {
+		return nil, errors.New("seed must be between 16 and 64 bytes")
+	}
+
+	if !isPrivateVersion(version) {
+		return nil, errors.New("master key version must be a private version")
+	}
+
+	mac := hmac.New(sha512.New, masterKey)
+	mac.Write(seed)
+	I := mac.Sum(nil)
+
+	k, err := keys.PrivateKeyFromBytes(c, I[:32])
+	if err != nil {
+		return nil, ErrInvalidMasterKey
+	}
+
+	return newPrivate(c, version, 0, [4]byte{}, 0, I[32:], k)
+}
+
+// newPrivate will create an extended private key, computing its Public Key.
+func newPrivate(c curve.Curve, version Version, depth uint8, parentFingerprint [4]byte,
+	childNumber uint32, chainCode []byte, k *keys.PrivateKey) (*ExtendedKey, error) {
+	pair, err := keys.NewFromPrivateKey(c, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExtendedKey{
+		curve:             c,
+		version:           version,
+		depth:             depth,
+		parentFingerprint: parentFingerprint,
+		childNumber:       childNumber,
+		chainCode:         chainCode,
+		privateKey:        k,
+		publicKey:         pair.PublicKey,
+	}, nil
+}
+
+// Child will derive the child extended key at index i. Indexes from
+// HardenedKeyStart are hardened and can only be derived from a private key.
+// ErrInvalidChild is returned for the rare indexes that do not produce a
+// valid key, in which case the next index should be used.
+func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
+	if k.depth == 255 {
+		return nil, ErrMaxDepth
+	}
+
+	hardened := i >= HardenedKeyStart
+	if hardened && !k.IsPrivate() {
+		return nil, ErrHardenedFromPublic
+	}
+
+	// Hardened children use 0x00 || ser256(k) and others use serP(K),
+	// followed by ser32(i).
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, 0x00)
+		data = append(data, k.privateKey.Bytes()...)
+	} else {
+		data = append(data, k.publicKey.CompressedSEC()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, i)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	I := mac.Sum(nil)
+	IL, chainCode := I[:32], I[32:]
+
+	// The child key is parse256(IL) + k, or point(parse256(IL)) + K for a
+	// public key. It is invalid if IL >= N or the result is zero.
+	if k.IsPrivate() {
+		child, err := k.privateKey.TweakAdd(k.curve, IL)
+		if err != nil {
+			return nil, ErrInvalidChild
+		}
+
+		return newPrivate(k.curve, k.version, k.depth+1, k.Fingerprint(), i, chainCode, child)
+	}
+
+	child, err := k.publicKey.TweakAdd(k.curve, IL)
+	if err != nil {
+		return nil, ErrInvalidChild
+	}
+
+	return &ExtendedKey{
+		curve:             k.curve,
+		version:           k.version,
+		depth:             k.depth + 1,
+		parentFingerprint: k.Fingerprint(),
+		childNumber:       i,
+		chainCode:         chainCode,
+		publicKey:         child,
+	}, nil
+}
+
+// Neuter will return the extended public key of k, with the public version
+// matching its private version. A public key is returned unchanged.
+func (k *ExtendedKey) Neuter() (*ExtendedKey, error) {
+	if !k.IsPrivate() {
+		return k, nil
+	}
+
+	info, ok := versionInfoFor(k.version)
+	if !ok {
+		return nil, errors.New("extended key has no public version")
+	}
+
+	return &ExtendedKey{
+		curve:             k.curve,
+		version:           info.public,
+		depth:             k.depth,
+		parentFingerprint: k.parentFingerprint,
+		childNumber:       k.childNumber,
+		chainCode:         k.chainCode,
+		publicKey:         k.publicKey,
+	}, nil
+}
+
+// IsPrivate reports whether k is an extended private key.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.privateKey != nil
+}
+
+// PrivateKey returns the Private Key of an extended private key.
+func (k *ExtendedKey) PrivateKey() (*keys.PrivateKey, error) {
+	if !k.IsPrivate() {
+		return nil, errors.New("extended key is not private")
+	}
+
+	return k.privateKey, nil
+}
+
+// PublicKey returns the Public Key of the extended key.
+func (k *ExtendedKey) PublicKey() *keys.PublicKey {
+	return k.publicKey
+}
+
+// Version returns the version bytes the extended key is serialized with.
+func (k *ExtendedKey) Version() Version {
+	return k.version
+}
+
+// Depth returns the number of derivations from the master key, which has a
+// depth of 0.
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+// ChildNumber returns the index the key was derived at from its parent.
+func (k *ExtendedKey) ChildNumber() uint32 {
+	return k.childNumber
+}
+
+// ParentFingerprint returns the fingerprint of the parent key, which is zero
+// for a master key.
+func (k *ExtendedKey) ParentFingerprint() [4]byte {
+	return k.parentFingerprint
+}
+
+// ChainCode returns a copy of the chain code.
+func (k *ExtendedKey) ChainCode() []byte {
+	return append([]byte{}, k.chainCode...)
+}
+
+// Identifier returns the Hash160 of the compressed Public Key.
+func (k *ExtendedKey) Identifier() []byte {
+	return utils.Hash160(k.publicKey.CompressedSEC())
+}
+
+// Fingerprint returns the first four bytes of the identifier.
+func (k *ExtendedKey) Fingerprint() [4]byte {
+	var fingerprint [4]byte
+	copy(fingerprint[:], k.Identifier())
+
+	return fingerprint
+}
+
+// IsChildOf reports whether k was derived directly from parent, by comparing
+// the depth and the parent fingerprint.
+func (k *ExtendedKey) IsChildOf(parent *ExtendedKey) bool {
+	return k.depth == parent.depth+1 && k.parentFingerprint == parent.Fingerprint()
+}
+
+// String returns the Base58Check serialization of the extended key.
+func (k *ExtendedKey) String() string {
+	b := make([]byte, 0, serializedKeyLen)
+	b = append(b, k.version[:]...)
+	b = append(b, k.depth)
+	b = append(b, k.parentFingerprint[:]...)
+	b = binary.BigEndian.AppendUint32(b, k.childNumber)
+	b = append(b, k.chainCode...)
+
+	if k.IsPrivate() {
+		b = append(b, 0x00)
+		b = append(b, k.privateKey.Bytes()...)
+	} else {
+		b = append(b, k.publicKey.CompressedSEC()...)
+	}
+
+	return utils.EncodeBase58Check(b)
+}
+
+// ParseExtendedKey will parse a Base58Check serialized extended key on the
+// curve c, checking its version, key and the depth of a master key.
+func ParseExtendedKey(c curve.Curve, s string) (*ExtendedKey, error) {
+	b, err := utils.DecodeBase58Check(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) != serializedKeyLen {
+		return nil, errors.New("serialized extended key has an invalid length")
+	}
+
+	k := &ExtendedKey{curve: c}
+	copy(k.version[:], b[:4])
+	k.depth = b[4]
+	copy(k.parentFingerprint[:], b[5:9])
+	k.childNumber = binary.BigEndian.Uint32(b[9:13])
+	k.chainCode = append([]byte{}, b[13:45]...)
+	key := b[45:]
+
+	// A master key has no parent.
+	if k.depth == 0 && (k.parentFingerprint != [4]byte{} || k.childNumber != 0) {
+		return nil, errors.New("extended key with a depth of 0 has a parent")
+	}
+
+	switch {
+	case isPrivateVersion(k.version):
+		if key[0] != 0x00 {
+			return nil, errors.New("extended private key does not start with 0x00")
+		}
+
+		privateKey, err := keys.PrivateKeyFromBytes(c, key[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		return newPrivate(c, k.version, k.depth, k.parentFingerprint, k.childNumber, k.chainCode, privateKey)
+
+	case isPublicVersion(k.version):
+		if key[0] != 0x02 && key[0] != 0x03 {
+			return nil, errors.New("extended public key is not a compressed sec public key")
+		}
+
+		k.publicKey, err = keys.ParseSEC(c, key)
+		if err != nil {
+			return nil, err
+		}
+
+		return k, nil
+	}
+
+	return nil, errors.New("unknown extended key version")
+}
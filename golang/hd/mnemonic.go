@@ -0,0 +1,170 @@
+package hd
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"golang.org/x/crypto/pbkdf2"
+	"math/big"
+	"strings"
+)
+
+const (
+	// pbkdf2Iterations and seedLen are the BIP 39 parameters for deriving a
+	// seed from a mnemonic.
+	pbkdf2Iterations = 2048
+	seedLen          = 64
+
+	// bitsPerWord is the number of bits of entropy and checksum encoded by
+	// each word of a mnemonic.
+	bitsPerWord = 11
+)
+
+// englishWordlist is the BIP 39 English wordlist, one word per line.
+//
+//go:embed english.txt
+var englishWordlist string
+
+var (
+	// wordList and wordIndex are the English wordlist and the index of each
+	// word in it.
+	wordList  = strings.Fields(englishWordlist)
+	wordIndex = func() map[string]int {
+		index := make(map[string]int, len(wordList))
+		for i, w := range wordList {
+			index[w] = i
+		}
+		return index
+	}()
+)
+
+var (
+	ErrInvalidEntropyLen = errors.New("entropy must be 128 to 256 bits and a multiple of 32 bits")
+	ErrInvalidMnemonic   = errors.New("mnemonic must be 12, 15, 18, 21 or 24 words from the wordlist")
+	ErrInvalidChecksum   = errors.New("mnemonic checksum is invalid")
+)
+
+// validEntropyLen reports whether n bits of entropy can be encoded as a
+// mnemonic.
+func validEntropyLen(n int) bool {
+	return n >= 128 && n <= 256 && n%32 == 0
+}
+
+// NewEntropy will return bits of random entropy from crypto/rand to create a
+// mnemonic from. bits must be 128 to 256 and a multiple of 32.
+func NewEntropy(bits int) ([]byte, error) {
+	if !validEntropyLen(bits) {
+		return nil, ErrInvalidEntropyLen
+	}
+
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, err
+	}
+
+	return entropy, nil
+}
+
+// NewMnemonic will encode the entropy as a mnemonic sentence of English words.
+// The first entropy bits / 32 bits of the SHA256 of the entropy are appended
+// as a checksum and each group of 11 bits is the index of a word.
+func NewMnemonic(entropy []byte) (string, error) {
+	entropyBits := len(entropy) * 8
+	if !validEntropyLen(entropyBits) {
+		return "", ErrInvalidEntropyLen
+	}
+	checksumBits := entropyBits / 32
+
+	hash := sha256.Sum256(entropy)
+	checksum := uint(hash[0] >> (8 - checksumBits))
+
+	b := new(big.Int).SetBytes(entropy)
+	b.Lsh(b, uint(checksumBits))
+	b.Or(b, new(big.Int).SetUint64(uint64(checksum)))
+
+	// Read the words from the last group of 11 bits to the first.
+	n := (entropyBits + checksumBits) / bitsPerWord
+	words := make([]string, n)
+	mask := big.NewInt(1<<bitsPerWord - 1)
+	word := new(big.Int)
+	for i := n - 1; i >= 0; i-- {
+		word.And(b, mask)
+		words[i] = wordList[word.Int64()]
+		b.Rsh(b, bitsPerWord)
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// EntropyFromMnemonic will decode a mnemonic sentence back to its entropy,
+// checking that every word is in the wordlist and that the checksum matches.
+func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	totalBits := len(words) * bitsPerWord
+	checksumBits := totalBits / 33
+	entropyBits := totalBits - checksumBits
+	if len(words)%3 != 0 || !validEntropyLen(entropyBits) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	b := new(big.Int)
+	for _, w := range words {
+		i, ok := wordIndex[w]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+
+		b.Lsh(b, bitsPerWord)
+		b.Or(b, big.NewInt(int64(i)))
+	}
+
+	checksum := new(big.Int).And(b, big.NewInt(1<<checksumBits-1)).Uint64()
+	b.Rsh(b, uint(checksumBits))
+
+	entropy := make([]byte, entropyBits/8)
+	b.FillBytes(entropy)
+
+	hash := sha256.Sum256(entropy)
+	if uint64(hash[0]>>(8-checksumBits)) != checksum {
+		return nil, ErrInvalidChecksum
+	}
+
+	return entropy, nil
+}
+
+// IsMnemonicValid reports whether mnemonic is a valid English mnemonic with a
+// matching checksum.
+func IsMnemonicValid(mnemonic string) bool {
+	_, err := EntropyFromMnemonic(mnemonic)
+	return err == nil
+}
+
+// NewSeed will derive a 64 byte seed from a mnemonic and an optional
+// passphrase using PBKDF2-HMAC-SHA512, with the salt "mnemonic" + passphrase
+// and 2048 iterations. The mnemonic is checked first, so that a mistyped
+// backup is not silently turned into a different wallet. The passphrase is
+// used as given, so a non-ASCII passphrase should already be NFKD normalized.
+func NewSeed(mnemonic, passphrase string) ([]byte, error) {
+	if _, err := EntropyFromMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+
+	sentence := strings.Join(strings.Fields(mnemonic), " ")
+	salt := "mnemonic" + passphrase
+
+	return pbkdf2.Key([]byte(sentence), []byte(salt), pbkdf2Iterations, seedLen, sha512.New), nil
+}
+
+// NewMasterFromMnemonic will create a master extended private key on the
+// curve c from the seed of a mnemonic and passphrase.
+func NewMasterFromMnemonic(c curve.Curve, mnemonic, passphrase string, version Version) (*ExtendedKey, error) {
+	seed, err := NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMaster(c, seed, version)
+}
@@ -0,0 +1,173 @@
+package hd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"strings"
+	"testing"
+)
+
+// bip39Vectors are a subset of the BIP 39 reference test vectors, which all
+// use the passphrase "TREZOR".
+var bip39Vectors = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		"80808080808080808080808080808080",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+		"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+	},
+	{
+		"ffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	},
+	{
+		"000000000000000000000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon agent",
+		"035895f2f481b1b0f01fcf8c289c794660b289981a78f8106447707fdd9666ca06da5a9a565181599b79f53b844d8a71dd9f439c52a3d7b3e8a79c906ac845fa",
+	},
+	{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		"bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+	},
+	{
+		"9e885d952ad362caeb4efe34a8e91bd2",
+		"ozone drill grab fiber curtain grace pudding thank cruise elder eight picnic",
+		"274ddc525802f7c828d8ef7ddbcdc5304e87ac3535913611fbbfa986d0c9e5476c91689f9c8a54fd55bd38606aa6a8595ad213d4c9c9f9aca3fb217069a41028",
+	},
+	{
+		"f585c11aec520db57dd353c69554b21a89b20fb0650966fa0a9d6f74fd989d8f",
+		"void come effort suffer camp survey warrior heavy shoot primary clutch crush open amazing screen patrol group space point ten exist slush involve unfold",
+		"01f5bced59dec48e362f2c45b5de68b9fd6c92c6634f44d6d40aab69056506f0e35524a518034ddc1192e1dacd32c1ed3eaa3c3b131c88ed8e7e54c49a5d0998",
+	},
+}
+
+// TestWordlist will test that the embedded wordlist has 2048 sorted words.
+func TestWordlist(t *testing.T) {
+	if len(wordList) != 2048 {
+		t.Fatalf("expected 2048 words, received: %v", len(wordList))
+	}
+
+	for i := 1; i < len(wordList); i++ {
+		if wordList[i-1] >= wordList[i] {
+			t.Fatalf("wordlist is not sorted at: %v", wordList[i])
+		}
+	}
+}
+
+// TestBIP39Vectors will test the mnemonic and seed of each test vector, and
+// that the mnemonic decodes back to its entropy.
+func TestBIP39Vectors(t *testing.T) {
+	for _, vector := range bip39Vectors {
+		entropy, _ := hex.DecodeString(vector.entropy)
+
+		mnemonic, err := NewMnemonic(entropy)
+		if err != nil {
+			t.Fatalf("failed to create mnemonic: %v", err)
+		}
+		if mnemonic != vector.mnemonic {
+			t.Fatalf("expected: %v, received: %v", vector.mnemonic, mnemonic)
+		}
+
+		decoded, err := EntropyFromMnemonic(mnemonic)
+		if err != nil {
+			t.Fatalf("failed to decode mnemonic: %v", err)
+		}
+		if !bytes.Equal(decoded, entropy) {
+			t.Fatalf("expected: %x, received: %x", entropy, decoded)
+		}
+
+		seed, err := NewSeed(mnemonic, "TREZOR")
+		if err != nil {
+			t.Fatalf("failed to create seed: %v", err)
+		}
+		if hex.EncodeToString(seed) != vector.seed {
+			t.Fatalf("expected: %v, received: %x", vector.seed, seed)
+		}
+	}
+}
+
+// TestNewMasterFromMnemonic will test that a mnemonic creates the master key
+// of the BIP 39 test vector.
+func TestNewMasterFromMnemonic(t *testing.T) {
+	key, err := NewMasterFromMnemonic(secp256k1.New(), bip39Vectors[0].mnemonic, "TREZOR", MainnetPrivate)
+	if err != nil {
+		t.Fatalf("failed to create master key: %v", err)
+	}
+
+	expected := "xprv9s21ZrQH143K3h3fDYiay8mocZ3afhfULfb5GX8kCBdno77K4HiA15Tg23wpbeF1pLfs1c5SPmYHrEpTuuRhxMwvKDwqdKiGJS9XFKzUsAF"
+	if key.String() != expected {
+		t.Fatalf("expected: %v, received: %v", expected, key)
+	}
+}
+
+// TestNewEntropy will test that each valid length of random entropy creates a
+// valid mnemonic of the expected number of words.
+func TestNewEntropy(t *testing.T) {
+	for bits := 128; bits <= 256; bits += 32 {
+		entropy, err := NewEntropy(bits)
+		if err != nil {
+			t.Fatalf("failed to create entropy: %v", err)
+		}
+
+		mnemonic, err := NewMnemonic(entropy)
+		if err != nil {
+			t.Fatalf("failed to create mnemonic: %v", err)
+		}
+
+		if len(strings.Fields(mnemonic)) != bits*33/32/11 {
+			t.Fatalf("unexpected number of words for %v bits: %v", bits, mnemonic)
+		}
+
+		if !IsMnemonicValid(mnemonic) {
+			t.Fatalf("mnemonic should be valid: %v", mnemonic)
+		}
+	}
+
+	for _, bits := range []int{0, 96, 130, 288} {
+		if _, err := NewEntropy(bits); err != ErrInvalidEntropyLen {
+			t.Fatalf("should have rejected %v bits", bits)
+		}
+	}
+}
+
+// TestInvalidMnemonic will test that mnemonics with the wrong number of words,
+// unknown words or a bad checksum are rejected.
+func TestInvalidMnemonic(t *testing.T) {
+	tests := []struct {
+		mnemonic string
+		err      error
+	}{
+		{"", ErrInvalidMnemonic},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", ErrInvalidMnemonic},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon bitcoin", ErrInvalidMnemonic},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon Abandon", ErrInvalidMnemonic},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", ErrInvalidChecksum},
+		{"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo", ErrInvalidChecksum},
+	}
+
+	for _, test := range tests {
+		if _, err := EntropyFromMnemonic(test.mnemonic); err != test.err {
+			t.Fatalf("%q: expected: %v, received: %v", test.mnemonic, test.err, err)
+		}
+
+		if _, err := NewSeed(test.mnemonic, ""); err == nil {
+			t.Fatalf("%q: seed should not be created from an invalid mnemonic", test.mnemonic)
+		}
+	}
+}
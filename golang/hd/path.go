@@ -0,0 +1,190 @@
+package hd
+
+import (
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"strconv"
+	"strings"
+)
+
+// Path is a BIP 32 derivation path, the child indexes to derive in order from
+// a master key. Hardened indexes include HardenedKeyStart.
+type Path []uint32
+
+// ParsePath will parse a derivation path such as "m/84'/0'/0'/0/5". Each
+// index may be followed by ' or h to mark it as hardened. "m" on its own is
+// the master key.
+func ParsePath(s string) (Path, error) {
+	parts := strings.Split(s, "/")
+	if parts[0] != "m" {
+		return nil, errors.New("derivation path must start with m")
+	}
+
+	path := make(Path, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") {
+			part = part[:len(part)-1]
+			offset = HardenedKeyStart
+		}
+
+		i, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(i) >= HardenedKeyStart {
+			return nil, errors.New("invalid derivation path index: " + part)
+		}
+
+		path = append(path, uint32(i)+offset)
+	}
+
+	return path, nil
+}
+
+// String returns the path in the form "m/84'/0'/0'/0/5".
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+
+	for _, i := range p {
+		b.WriteString("/")
+		if i >= HardenedKeyStart {
+			b.WriteString(strconv.FormatUint(uint64(i-HardenedKeyStart), 10))
+			b.WriteString("'")
+			continue
+		}
+		b.WriteString(strconv.FormatUint(uint64(i), 10))
+	}
+
+	return b.String()
+}
+
+// AddressType returns the address type of the purpose, the first index, of a
+// BIP 44, 49, 84 or 86 path.
+func (p Path) AddressType() (AddressType, error) {
+	if len(p) == 0 {
+		return 0, errors.New("derivation path has no purpose")
+	}
+
+	for t, purpose := range purposes {
+		if p[0] == HardenedKeyStart+purpose {
+			return t, nil
+		}
+	}
+
+	return 0, errors.New("derivation path has an unknown purpose")
+}
+
+// DerivePath will derive each index of the path in order, starting from k.
+func (k *ExtendedKey) DerivePath(p Path) (*ExtendedKey, error) {
+	key := k
+	for _, i := range p {
+		var err error
+		key, err = key.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// AddressType is the type of address produced by the keys of an account.
+type AddressType int
+
+const (
+	P2PKH      AddressType = iota // BIP 44
+	P2SHP2WPKH                    // BIP 49
+	P2WPKH                        // BIP 84
+	P2TR                          // BIP 86
+)
+
+// purposes maps each address type to the purpose of the BIP that defines its
+// account layout.
+var purposes = map[AddressType]uint32{
+	P2PKH:      44,
+	P2SHP2WPKH: 49,
+	P2WPKH:     84,
+	P2TR:       86,
+}
+
+// Purpose returns the first, hardened, index of paths for the address type.
+func (t AddressType) Purpose() uint32 {
+	return purposes[t]
+}
+
+// AccountPath returns the path m/purpose'/coin_type'/account' of an account
+// for the address type. The coin type is 0 for mainnet and 1 for testnet.
+func AccountPath(t AddressType, testnet bool, account uint32) (Path, error) {
+	purpose, ok := purposes[t]
+	if !ok {
+		return nil, errors.New("unknown address type")
+	}
+
+	if account >= HardenedKeyStart {
+		return nil, errors.New("account must be less than HardenedKeyStart")
+	}
+
+	var coinType uint32
+	if testnet {
+		coinType = 1
+	}
+
+	return Path{
+		HardenedKeyStart + purpose,
+		HardenedKeyStart + coinType,
+		HardenedKeyStart + account,
+	}, nil
+}
+
+// AddressPath returns the path m/purpose'/coin_type'/account'/change/index of
+// an address for the address type. change selects the internal chain used for
+// change outputs instead of the external chain used for receiving.
+func AddressPath(t AddressType, testnet bool, account uint32, change bool, index uint32) (Path, error) {
+	path, err := AccountPath(t, testnet, account)
+	if err != nil {
+		return nil, err
+	}
+
+	if index >= HardenedKeyStart {
+		return nil, errors.New("index must be less than HardenedKeyStart")
+	}
+
+	var chain uint32
+	if change {
+		chain = 1
+	}
+
+	return append(path, chain, index), nil
+}
+
+// Address will generate the address of type t for the Public Key of k.
+func (k *ExtendedKey) Address(t AddressType, testnet bool) (string, error) {
+	sec := k.publicKey.CompressedSEC()
+
+	switch t {
+	case P2PKH:
+		if testnet {
+			return keys.GenerateTestnetAddress(sec), nil
+		}
+		return keys.GenerateMainnetAddress(sec), nil
+
+	case P2SHP2WPKH:
+		if testnet {
+			return keys.GenerateTestnetP2SHP2WPKHAddress(sec), nil
+		}
+		return keys.GenerateMainnetP2SHP2WPKHAddress(sec), nil
+
+	case P2WPKH:
+		if testnet {
+			return keys.GenerateTestnetP2WPKHAddress(sec)
+		}
+		return keys.GenerateMainnetP2WPKHAddress(sec)
+
+	case P2TR:
+		if testnet {
+			return keys.GenerateTestnetP2TRAddress(k.curve, sec)
+		}
+		return keys.GenerateMainnetP2TRAddress(k.curve, sec)
+	}
+
+	return "", errors.New("unknown address type")
+}
@@ -0,0 +1,164 @@
+package hd
+
+import (
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"testing"
+)
+
+// TestParsePath will test that paths parse with either hardened marker and
+// are formatted back with '.
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected Path
+		str      string
+	}{
+		{"m", Path{}, "m"},
+		{"m/0", Path{0}, "m/0"},
+		{"m/84'/0'/0'/0/5", Path{HardenedKeyStart + 84, HardenedKeyStart, HardenedKeyStart, 0, 5}, "m/84'/0'/0'/0/5"},
+		{"m/84h/0h/0h/1/5", Path{HardenedKeyStart + 84, HardenedKeyStart, HardenedKeyStart, 1, 5}, "m/84'/0'/0'/1/5"},
+		{"m/2147483647'/2147483647", Path{0xffffffff, 0x7fffffff}, "m/2147483647'/2147483647"},
+	}
+
+	for _, test := range tests {
+		path, err := ParsePath(test.path)
+		if err != nil {
+			t.Fatalf("failed to parse %v: %v", test.path, err)
+		}
+
+		if len(path) != len(test.expected) {
+			t.Fatalf("%v: expected: %v, received: %v", test.path, test.expected, path)
+		}
+		for i := range path {
+			if path[i] != test.expected[i] {
+				t.Fatalf("%v: expected: %v, received: %v", test.path, test.expected, path)
+			}
+		}
+
+		if path.String() != test.str {
+			t.Fatalf("expected: %v, received: %v", test.str, path)
+		}
+	}
+}
+
+// TestParsePathInvalid will test that malformed paths and out of range indexes
+// are rejected.
+func TestParsePathInvalid(t *testing.T) {
+	for _, path := range []string{
+		"",
+		"M/0",
+		"0/1",
+		"m/",
+		"m//0",
+		"m/'",
+		"m/-1",
+		"m/+1",
+		"m/0x10",
+		"m/1''",
+		"m/1H",
+		"m/2147483648",
+		"m/2147483648'",
+		"m/4294967296",
+	} {
+		if _, err := ParsePath(path); err == nil {
+			t.Fatalf("should have rejected: %q", path)
+		}
+	}
+}
+
+// TestAddressPath will test the account and address paths of each scheme and
+// that the address type is recovered from the purpose.
+func TestAddressPath(t *testing.T) {
+	tests := []struct {
+		addressType AddressType
+		testnet     bool
+		account     uint32
+		change      bool
+		index       uint32
+		expected    string
+	}{
+		{P2PKH, false, 0, false, 0, "m/44'/0'/0'/0/0"},
+		{P2SHP2WPKH, true, 0, false, 1, "m/49'/1'/0'/0/1"},
+		{P2WPKH, false, 2, true, 5, "m/84'/0'/2'/1/5"},
+		{P2TR, true, 1, true, 0, "m/86'/1'/1'/1/0"},
+	}
+
+	for _, test := range tests {
+		path, err := AddressPath(test.addressType, test.testnet, test.account, test.change, test.index)
+		if err != nil {
+			t.Fatalf("failed to create path: %v", err)
+		}
+
+		if path.String() != test.expected {
+			t.Fatalf("expected: %v, received: %v", test.expected, path)
+		}
+
+		addressType, err := path.AddressType()
+		if err != nil || addressType != test.addressType {
+			t.Fatalf("%v: expected address type: %v, received: %v, %v", path, test.addressType, addressType, err)
+		}
+	}
+
+	if _, err := AccountPath(P2WPKH, false, HardenedKeyStart); err == nil {
+		t.Fatalf("should have rejected a hardened account")
+	}
+
+	if _, err := AddressPath(P2WPKH, false, 0, false, HardenedKeyStart); err == nil {
+		t.Fatalf("should have rejected a hardened index")
+	}
+
+	if _, err := AccountPath(AddressType(-1), false, 0); err == nil {
+		t.Fatalf("should have rejected an unknown address type")
+	}
+
+	if _, err := (Path{HardenedKeyStart + 45}).AddressType(); err == nil {
+		t.Fatalf("should have rejected an unknown purpose")
+	}
+}
+
+// TestDeriveAddresses will test the addresses derived from the BIP 44, 49, 84
+// and 86 test vectors, which use the mnemonic "abandon ... about" with no
+// passphrase.
+func TestDeriveAddresses(t *testing.T) {
+	tests := []struct {
+		path        string
+		addressType AddressType
+		testnet     bool
+		expected    string
+	}{
+		{"m/44'/0'/0'/0/0", P2PKH, false, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+		{"m/49'/1'/0'/0/0", P2SHP2WPKH, true, "2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2"},
+		{"m/84'/0'/0'/0/0", P2WPKH, false, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{"m/84'/0'/0'/0/1", P2WPKH, false, "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"},
+		{"m/84'/0'/0'/1/0", P2WPKH, false, "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el"},
+		{"m/86'/0'/0'/0/0", P2TR, false, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+		{"m/86'/0'/0'/0/1", P2TR, false, "bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh"},
+		{"m/86'/0'/0'/1/0", P2TR, false, "bc1p3qkhfews2uk44qtvauqyr2ttdsw7svhkl9nkm9s9c3x4ax5h60wqwruhk7"},
+	}
+
+	master, err := NewMasterFromMnemonic(secp256k1.New(), bip39Vectors[0].mnemonic, "", MainnetPrivate)
+	if err != nil {
+		t.Fatalf("failed to create master key: %v", err)
+	}
+
+	for _, test := range tests {
+		path, err := ParsePath(test.path)
+		if err != nil {
+			t.Fatalf("failed to parse %v: %v", test.path, err)
+		}
+
+		key, err := master.DerivePath(path)
+		if err != nil {
+			t.Fatalf("failed to derive %v: %v", test.path, err)
+		}
+
+		address, err := key.Address(test.addressType, test.testnet)
+		if err != nil {
+			t.Fatalf("failed to generate address for %v: %v", test.path, err)
+		}
+
+		if address != test.expected {
+			t.Fatalf("%v: expected: %v, received: %v", test.path, test.expected, address)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package hd
+
+import (
+	"errors"
+)
+
+// The SLIP 132 versions of single signature accounts. The BIP 32 xprv, xpub,
+// tprv and tpub versions are used for P2PKH.
+var (
+	MainnetP2SHP2WPKHPrivate = Version{0x04, 0x9d, 0x78, 0x78} // yprv
+	MainnetP2SHP2WPKHPublic  = Version{0x04, 0x9d, 0x7c, 0xb2} // ypub
+	MainnetP2WPKHPrivate     = Version{0x04, 0xb2, 0x43, 0x0c} // zprv
+	MainnetP2WPKHPublic      = Version{0x04, 0xb2, 0x47, 0x46} // zpub
+	TestnetP2SHP2WPKHPrivate = Version{0x04, 0x4a, 0x4e, 0x28} // uprv
+	TestnetP2SHP2WPKHPublic  = Version{0x04, 0x4a, 0x52, 0x62} // upub
+	TestnetP2WPKHPrivate     = Version{0x04, 0x5f, 0x18, 0xbc} // vprv
+	TestnetP2WPKHPublic      = Version{0x04, 0x5f, 0x1c, 0xf6} // vpub
+)
+
+// versionInfo is a pair of private and public versions and the address type
+// and network they are used for.
+type versionInfo struct {
+	private     Version
+	public      Version
+	addressType AddressType
+	testnet     bool
+}
+
+// versions are the known versions. P2TR has no version of its own and is
+// serialized with the P2PKH versions, so it is not listed.
+var versions = []versionInfo{
+	{MainnetPrivate, MainnetPublic, P2PKH, false},
+	{MainnetP2SHP2WPKHPrivate, MainnetP2SHP2WPKHPublic, P2SHP2WPKH, false},
+	{MainnetP2WPKHPrivate, MainnetP2WPKHPublic, P2WPKH, false},
+	{TestnetPrivate, TestnetPublic, P2PKH, true},
+	{TestnetP2SHP2WPKHPrivate, TestnetP2SHP2WPKHPublic, P2SHP2WPKH, true},
+	{TestnetP2WPKHPrivate, TestnetP2WPKHPublic, P2WPKH, true},
+}
+
+// versionInfoFor returns the versionInfo that v is the private or public
+// version of.
+func versionInfoFor(v Version) (versionInfo, bool) {
+	for _, info := range versions {
+		if info.private == v || info.public == v {
+			return info, true
+		}
+	}
+
+	return versionInfo{}, false
+}
+
+// isPrivateVersion reports whether v is a known private version.
+func isPrivateVersion(v Version) bool {
+	info, ok := versionInfoFor(v)
+	return ok && info.private == v
+}
+
+// isPublicVersion reports whether v is a known public version.
+func isPublicVersion(v Version) bool {
+	info, ok := versionInfoFor(v)
+	return ok && info.public == v
+}
+
+// VersionFor returns the private or public version for the address type and
+// network. P2TR uses the P2PKH versions.
+func VersionFor(t AddressType, testnet, private bool) (Version, error) {
+	if t == P2TR {
+		t = P2PKH
+	}
+
+	for _, info := range versions {
+		if info.addressType != t || info.testnet != testnet {
+			continue
+		}
+
+		if private {
+			return info.private, nil
+		}
+		return info.public, nil
+	}
+
+	return Version{}, errors.New("no version for the address type")
+}
+
+// AddressType returns the address type of the key's version. Keys serialized
+// with xpub or tpub are reported as P2PKH, although they are also used for
+// P2TR.
+func (k *ExtendedKey) AddressType() AddressType {
+	info, _ := versionInfoFor(k.version)
+	return info.addressType
+}
+
+// IsTestnet reports whether the key's version is a testnet version.
+func (k *ExtendedKey) IsTestnet() bool {
+	info, _ := versionInfoFor(k.version)
+	return info.testnet
+}
+
+// Convert will return a copy of k with the version for the address type and
+// network, such as converting a zpub to an xpub. The key and chain code are
+// unchanged and a private key stays private.
+func (k *ExtendedKey) Convert(t AddressType, testnet bool) (*ExtendedKey, error) {
+	version, err := VersionFor(t, testnet, k.IsPrivate())
+	if err != nil {
+		return nil, err
+	}
+
+	converted := *k
+	converted.version = version
+
+	return &converted, nil
+}
@@ -0,0 +1,142 @@
+package hd
+
+import (
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"strings"
+	"testing"
+)
+
+// TestSLIP132Versions will test that every version serializes with its SLIP
+// 132 prefix, parses back to its address type and network, and is neutered to
+// the matching public version.
+func TestSLIP132Versions(t *testing.T) {
+	curve := secp256k1.New()
+
+	tests := []struct {
+		addressType AddressType
+		testnet     bool
+		private     string
+		public      string
+	}{
+		{P2PKH, false, "xprv", "xpub"},
+		{P2SHP2WPKH, false, "yprv", "ypub"},
+		{P2WPKH, false, "zprv", "zpub"},
+		{P2PKH, true, "tprv", "tpub"},
+		{P2SHP2WPKH, true, "uprv", "upub"},
+		{P2WPKH, true, "vprv", "vpub"},
+	}
+
+	seed := make([]byte, minSeedLen)
+	for _, test := range tests {
+		version, err := VersionFor(test.addressType, test.testnet, true)
+		if err != nil {
+			t.Fatalf("failed to get version for %v: %v", test.private, err)
+		}
+
+		key, err := NewMaster(curve, seed, version)
+		if err != nil {
+			t.Fatalf("failed to create %v master key: %v", test.private, err)
+		}
+
+		pub, err := key.Neuter()
+		if err != nil {
+			t.Fatalf("failed to neuter %v: %v", test.private, err)
+		}
+
+		for _, k := range []struct {
+			s      string
+			prefix string
+		}{
+			{key.String(), test.private},
+			{pub.String(), test.public},
+		} {
+			if !strings.HasPrefix(k.s, k.prefix) {
+				t.Fatalf("expected prefix: %v, received: %v", k.prefix, k.s)
+			}
+
+			parsed, err := ParseExtendedKey(curve, k.s)
+			if err != nil {
+				t.Fatalf("failed to parse %v: %v", k.s, err)
+			}
+
+			if parsed.AddressType() != test.addressType || parsed.IsTestnet() != test.testnet {
+				t.Fatalf("%v: expected: %v testnet %v, received: %v testnet %v", k.prefix,
+					test.addressType, test.testnet, parsed.AddressType(), parsed.IsTestnet())
+			}
+		}
+	}
+}
+
+// TestConvert will test converting the BIP 84 test vector account keys between
+// zprv/zpub and xprv/xpub, and that the converted key derives the same
+// addresses.
+func TestConvert(t *testing.T) {
+	curve := secp256k1.New()
+	zprv := "zprvAdG4iTXWBoARxkkzNpNh8r6Qag3irQB8PzEMkAFeTRXxHpbF9z4QgEvBRmfvqWvGp42t42nvgGpNgYSJA9iefm1yYNZKEm7z6qUWCroSQnE"
+	zpub := "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"
+
+	master, err := NewMasterFromMnemonic(curve, bip39Vectors[0].mnemonic, "", MainnetPrivate)
+	if err != nil {
+		t.Fatalf("failed to create master key: %v", err)
+	}
+
+	path, _ := AccountPath(P2WPKH, false, 0)
+	account, err := master.DerivePath(path)
+	if err != nil {
+		t.Fatalf("failed to derive account: %v", err)
+	}
+
+	private, err := account.Convert(P2WPKH, false)
+	if err != nil || private.String() != zprv {
+		t.Fatalf("expected: %v, received: %v, %v", zprv, private, err)
+	}
+
+	public, err := private.Neuter()
+	if err != nil || public.String() != zpub {
+		t.Fatalf("expected: %v, received: %v, %v", zpub, public, err)
+	}
+
+	// Converting the parsed zpub to an xpub and back should be lossless.
+	parsed, err := ParseExtendedKey(curve, zpub)
+	if err != nil {
+		t.Fatalf("failed to parse zpub: %v", err)
+	}
+
+	xpub, err := parsed.Convert(P2PKH, false)
+	if err != nil || !strings.HasPrefix(xpub.String(), "xpub") {
+		t.Fatalf("failed to convert to xpub: %v, %v", xpub, err)
+	}
+
+	back, err := xpub.Convert(parsed.AddressType(), parsed.IsTestnet())
+	if err != nil || back.String() != zpub {
+		t.Fatalf("expected: %v, received: %v, %v", zpub, back, err)
+	}
+
+	if parsed.Version() != MainnetP2WPKHPublic {
+		t.Fatalf("expected zpub version, received: %x", parsed.Version())
+	}
+
+	// The zpub derives the first receiving address of the BIP 84 test vector.
+	child, err := parsed.DerivePath(Path{0, 0})
+	if err != nil {
+		t.Fatalf("failed to derive from zpub: %v", err)
+	}
+
+	address, err := child.Address(parsed.AddressType(), parsed.IsTestnet())
+	if err != nil || address != "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu" {
+		t.Fatalf("unexpected address: %v, %v", address, err)
+	}
+}
+
+// TestVersionFor will test that P2TR uses the P2PKH versions and that an
+// unknown address type is rejected.
+func TestVersionFor(t *testing.T) {
+	version, err := VersionFor(P2TR, true, false)
+	if err != nil || version != TestnetPublic {
+		t.Fatalf("expected tpub version, received: %x, %v", version, err)
+	}
+
+	if _, err := VersionFor(AddressType(-1), false, false); err == nil {
+		t.Fatalf("should have rejected an unknown address type")
+	}
+}
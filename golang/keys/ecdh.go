@@ -0,0 +1,54 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+)
+
+// ECDHHashFunc derives a shared secret from the 32 byte big-endian x and y
+// co-ordinates of the shared point.
+type ECDHHashFunc func(x, y []byte) []byte
+
+// ECDHSHA256 is the default ECDHHashFunc used by libsecp256k1, the SHA256 of
+// the compressed SEC serialization of the shared point.
+func ECDHSHA256(x, y []byte) []byte {
+	version := byte(0x02 | (y[31] & 0x01))
+
+	h := sha256.New()
+	h.Write([]byte{version})
+	h.Write(x)
+
+	return h.Sum(nil)
+}
+
+// ECDH will compute the shared secret of the Private Key pk and the Public
+// Key pub on the curve c. It is the SHA256 of the compressed shared point
+// pk*pub, which is compatible with secp256k1_ecdh in libsecp256k1.
+func ECDH(c curve.Curve, pk *PrivateKey, pub *PublicKey) ([]byte, error) {
+	return ECDHWithHash(c, pk, pub, ECDHSHA256)
+}
+
+// ECDHWithHash will compute the shared point pk*pub on the curve c and return
+// its co-ordinates passed through hashFn.
+func ECDHWithHash(c curve.Curve, pk *PrivateKey, pub *PublicKey, hashFn ECDHHashFunc) ([]byte, error) {
+	// Multiplying a point that is not on the curve could leak the secret.
+	if !c.IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.New("ecdh public key is not on the curve")
+	}
+
+	x, y, z := c.ScalarMult(pub.X, pub.Y, pk.secret.Bytes())
+	if z.Sign() == 0 {
+		return nil, errors.New("ecdh shared point is the point at infinity")
+	}
+
+	sx, sy := c.AffineFromJacobian(x, y, z)
+
+	return hashFn(padTo32(sx.Bytes()), padTo32(sy.Bytes())), nil
+}
+
+// ECDH will compute the shared secret of the key pair and the Public Key pub
+// as the package level ECDH does.
+func (k *Keys) ECDH(pub *PublicKey) ([]byte, error) {
+	return ECDH(k.Curve, k.PrivateKey, pub)
+}
@@ -0,0 +1,102 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/p256"
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"math/big"
+	"testing"
+)
+
+// TestECDH will test that both parties derive the same shared secret on
+// either curve.
+func TestECDH(t *testing.T) {
+	for _, c := range []curve.Curve{secp256k1.New(), p256.New()} {
+		for i := 0; i < 5; i++ {
+			alice, err := New(c)
+			if err != nil {
+				t.Fatalf("failed to generate keys: %v", err)
+			}
+			bob, err := New(c)
+			if err != nil {
+				t.Fatalf("failed to generate keys: %v", err)
+			}
+
+			a, err := alice.ECDH(bob.PublicKey)
+			if err != nil {
+				t.Fatalf("%v: failed to compute ecdh: %v", c.Params().Name, err)
+			}
+			b, err := ECDH(c, bob.PrivateKey, alice.PublicKey)
+			if err != nil {
+				t.Fatalf("%v: failed to compute ecdh: %v", c.Params().Name, err)
+			}
+
+			if len(a) != 32 || !bytes.Equal(a, b) {
+				t.Fatalf("%v: shared secrets do not match: %x, %x", c.Params().Name, a, b)
+			}
+		}
+	}
+}
+
+// TestECDHKnownValue will test that a secret of 1 with the generator point
+// gives the SHA256 of the compressed generator point, as libsecp256k1 does.
+func TestECDHKnownValue(t *testing.T) {
+	curve := secp256k1.New()
+	pk := &PrivateKey{secret: big.NewInt(1)}
+	pub := testPublicKey(t, curve, curve.Gx, curve.Gy)
+
+	secret, err := ECDH(curve, pk, pub)
+	if err != nil {
+		t.Fatalf("failed to compute ecdh: %v", err)
+	}
+
+	expected := "0f715baf5d4c2ed329785cef29e562f73488c8a2bb9dbc5700b361d54b9b0554"
+	if hex.EncodeToString(secret) != expected {
+		t.Fatalf("expected: %v, received: %x", expected, secret)
+	}
+}
+
+// TestECDHWithHash will test that the custom hash function receives the
+// co-ordinates of the shared point.
+func TestECDHWithHash(t *testing.T) {
+	curve := secp256k1.New()
+	alice, _ := New(curve)
+	bob, _ := New(curve)
+
+	raw := func(x, y []byte) []byte {
+		return append(append([]byte{}, x...), y...)
+	}
+
+	shared, err := ECDHWithHash(curve, alice.PrivateKey, bob.PublicKey, raw)
+	if err != nil {
+		t.Fatalf("failed to compute ecdh: %v", err)
+	}
+
+	x, y := curve.AffineFromJacobian(curve.ScalarMult(bob.PublicKey.X, bob.PublicKey.Y, alice.PrivateKey.secret.Bytes()))
+	if new(big.Int).SetBytes(shared[:32]).Cmp(x) != 0 || new(big.Int).SetBytes(shared[32:]).Cmp(y) != 0 {
+		t.Fatalf("expected: (%x, %x), received: %x", x, y, shared)
+	}
+
+	// The default hash is the SHA256 of the compressed point.
+	secret, _ := ECDH(curve, alice.PrivateKey, bob.PublicKey)
+	if !bytes.Equal(secret, ECDHSHA256(shared[:32], shared[32:])) {
+		t.Fatalf("default hash does not match the compressed shared point")
+	}
+}
+
+// TestECDHInvalidPublicKey will test that a Public Key not on the curve is
+// rejected.
+func TestECDHInvalidPublicKey(t *testing.T) {
+	curve := secp256k1.New()
+	k, _ := New(curve)
+
+	pub := &PublicKey{}
+	pub.X = new(big.Int).Set(curve.Gx)
+	pub.Y = new(big.Int).Add(curve.Gy, big.NewInt(1))
+
+	if _, err := k.ECDH(pub); err == nil {
+		t.Fatalf("should have rejected a public key not on the curve")
+	}
+}
@@ -3,37 +3,73 @@ package keys
 import (
 	"crypto/rand"
 	"errors"
-	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
 	"github.com/ccdle12/bitcoin-review/golang/utils"
 	"math/big"
 )
 
 // Keys contains a Private Key and Public Key.
 type Keys struct {
-	Curve      *secp256k1.Secp256k1 // TODO: replace this with Curve interface
+	Curve      curve.Curve
 	PrivateKey *PrivateKey
 	PublicKey  *PublicKey
 }
 
 // New is the constructor for creating a key pair. It will generate a Private
-// Key and Public Key pair.
-// TODO: Pass a curve as an argument construct.
-func New() (*Keys, error) {
-	// Generate a curve.
-	secp256k1 := secp256k1.New()
-
+// Key and Public Key pair on the curve c.
+func New(c curve.Curve) (*Keys, error) {
 	// 1. Generate a private key and assign.
-	privateKey, err := generatePrivateKey(secp256k1)
+	privateKey, err := generatePrivateKey(c)
 	if err != nil {
 		return nil, err
 	}
 	// 2. Generate a public key and assign.
-	publicKey, err := generatePublicKey(secp256k1, privateKey)
+	publicKey, err := generatePublicKey(c, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Keys{c, privateKey, publicKey}, err
+}
+
+// NewFromPrivateKey will create a key pair from an existing Private Key on
+// the curve c, such as one restored from a backup.
+func NewFromPrivateKey(c curve.Curve, pk *PrivateKey) (*Keys, error) {
+	publicKey, err := generatePublicKey(c, pk)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Keys{secp256k1, privateKey, publicKey}, err
+	return &Keys{c, pk, publicKey}, nil
+}
+
+// NewBatch will generate n key pairs on the curve c. The Public Keys are
+// converted to affine co-ordinates together with a single modular inversion,
+// which is much faster than calling New n times.
+func NewBatch(c curve.Curve, n int) ([]*Keys, error) {
+	privateKeys := make([]*PrivateKey, n)
+	points := make([]*curve.JacobianPoint, n)
+	for i := range privateKeys {
+		privateKey, err := generatePrivateKey(c)
+		if err != nil {
+			return nil, err
+		}
+
+		x, y, z := c.ScalarBaseMult(privateKey.secret.Bytes())
+		privateKeys[i] = privateKey
+		points[i] = &curve.JacobianPoint{Curve: c, X: x, Y: y, Z: z}
+	}
+
+	keys := make([]*Keys, n)
+	for i, p := range curve.BatchAffine(points) {
+		if p.IsInfinity() || !c.IsOnCurve(p.X, p.Y) {
+			return nil, errors.New("the public key generated is not on the curve and therefore invalid")
+		}
+
+		keys[i] = &Keys{c, privateKeys[i], &PublicKey{Point: *p}}
+	}
+
+	return keys, nil
 }
 
 // PrivateKey is the struct to hold Private Key information.
@@ -41,44 +77,166 @@ type PrivateKey struct {
 	secret *big.Int
 }
 
-// PublicKey is the struct that holds Public Key information.
+// PrivateKeyFromBytes will create a Private Key from a big-endian secret,
+// checking that it is in the range [1, N) of the curve c.
+func PrivateKeyFromBytes(c curve.Curve, b []byte) (*PrivateKey, error) {
+	secret := new(big.Int).SetBytes(b)
+	if secret.Sign() == 0 || secret.Cmp(c.Params().N) >= 0 {
+		return nil, errors.New("private key secret is out of range")
+	}
+
+	return &PrivateKey{secret: secret}, nil
+}
+
+// Secret returns a copy of the Private Key secret.
+func (pk *PrivateKey) Secret() *big.Int {
+	return new(big.Int).Set(pk.secret)
+}
+
+// PublicKey is the struct that holds Public Key information. It is a point
+// on the curve that is not the point at infinity.
 type PublicKey struct {
-	X *big.Int
-	Y *big.Int
+	curve.Point
 }
 
-// generatePrivateKey will generate a new Private Key.
-// TODO: Update the parameter to use the Curve interface
-func generatePrivateKey(curve *secp256k1.Secp256k1) (*PrivateKey, error) {
+// NewPublicKey will create a Public Key from affine co-ordinates, checking
+// that they are a point on the curve c.
+func NewPublicKey(c curve.Curve, x, y *big.Int) (*PublicKey, error) {
+	p, err := curve.NewPoint(c, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicKey{Point: *p}, nil
+}
+
+// PublicKeyFromPoint will create a Public Key from a point, rejecting the
+// point at infinity.
+func PublicKeyFromPoint(p *curve.Point) (*PublicKey, error) {
+	if p.IsInfinity() {
+		return nil, errors.New("public key is the point at infinity")
+	}
+
+	return &PublicKey{Point: *p}, nil
+}
+
+// generatePrivateKey will generate a new Private Key in the range [1, N).
+func generatePrivateKey(c curve.Curve) (*PrivateKey, error) {
 	// Generate the Private Key secret.
-	secret, err := rand.Int(rand.Reader, curve.N)
+	max := new(big.Int).Sub(c.Params().N, big.NewInt(1))
+	secret, err := rand.Int(rand.Reader, max)
 	if err != nil {
 		return nil, errors.New("failed to generate a private key")
 	}
+	secret.Add(secret, big.NewInt(1))
 
 	return &PrivateKey{secret: secret}, nil
 }
 
 // generatePublicKey will generate a new Public Key.
-// TODO: Update the parameter to use the Curve interface
-func generatePublicKey(curve *secp256k1.Secp256k1, pk *PrivateKey) (*PublicKey, error) {
-
-	jx, jy, jz := curve.ScalarMult(pk.secret.Bytes())
-	x, y := curve.AffineFromJacobian(jx, jy, jz)
+func generatePublicKey(c curve.Curve, pk *PrivateKey) (*PublicKey, error) {
+	jx, jy, jz := c.ScalarBaseMult(pk.secret.Bytes())
+	x, y := c.AffineFromJacobian(jx, jy, jz)
 
-	validPoint := curve.IsOnCurve(x, y)
+	validPoint := c.IsOnCurve(x, y)
 	if !validPoint {
 		return nil, errors.New("the public key generated is not on the curve and therefore invalid")
 	}
 
-	return &PublicKey{X: x, Y: y}, nil
+	return &PublicKey{Point: curve.Point{Curve: c, X: x, Y: y}}, nil
+}
+
+// UncompressedSEC returns the uncompressed SEC serialization of the Public
+// Key.
+func (pub *PublicKey) UncompressedSEC() []byte {
+	return generateUncompressedSec(pub)
+}
+
+// CompressedSEC returns the compressed SEC serialization of the Public Key.
+func (pub *PublicKey) CompressedSEC() []byte {
+	return generateCompressedSec(pub)
+}
+
+// ParseSEC will parse a 33 byte compressed or 65 byte uncompressed SEC
+// formatted Public Key on the curve c. Hybrid encodings (0x06 and 0x07) are
+// rejected.
+func ParseSEC(c curve.Curve, sec []byte) (*PublicKey, error) {
+	if len(sec) == 0 {
+		return nil, errors.New("sec public key is empty")
+	}
+
+	p := c.Params().P
+
+	switch sec[0] {
+	case 0x02, 0x03:
+		if len(sec) != 33 {
+			return nil, errors.New("compressed sec public key must be 33 bytes")
+		}
+
+		x := new(big.Int).SetBytes(sec[1:])
+		if x.Cmp(p) >= 0 {
+			return nil, errors.New("sec public key x co-ordinate is not in the field")
+		}
+
+		// Compute y from x, 0x03 marks an odd y.
+		y, err := c.DecompressY(x, sec[0] == 0x03)
+		if err != nil {
+			return nil, err
+		}
+
+		return &PublicKey{Point: curve.Point{Curve: c, X: x, Y: y}}, nil
+
+	case 0x04:
+		if len(sec) != 65 {
+			return nil, errors.New("uncompressed sec public key must be 65 bytes")
+		}
+
+		x := new(big.Int).SetBytes(sec[1:33])
+		y := new(big.Int).SetBytes(sec[33:])
+		if x.Cmp(p) >= 0 || y.Cmp(p) >= 0 {
+			return nil, errors.New("sec public key co-ordinates are not in the field")
+		}
+
+		if !c.IsOnCurve(x, y) {
+			return nil, errors.New("sec public key is not on the curve")
+		}
+
+		return &PublicKey{Point: curve.Point{Curve: c, X: x, Y: y}}, nil
+
+	case 0x06, 0x07:
+		return nil, errors.New("hybrid sec public keys are not supported")
+	}
+
+	return nil, errors.New("invalid sec public key prefix")
+}
+
+// XOnly returns the BIP 340 x-only serialization of the Public Key, the 32
+// byte x co-ordinate.
+func (pub *PublicKey) XOnly() []byte {
+	return padTo32(pub.X.Bytes())
+}
+
+// PublicKeyFromXOnly will parse a BIP 340 x-only Public Key on the curve c,
+// choosing the point with an even y co-ordinate.
+func PublicKeyFromXOnly(c curve.Curve, b []byte) (*PublicKey, error) {
+	if len(b) != 32 {
+		return nil, errors.New("x-only public key must be 32 bytes")
+	}
+
+	x := new(big.Int).SetBytes(b)
+	y, err := c.DecompressY(x, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicKey{Point: curve.Point{Curve: c, X: x, Y: y}}, nil
 }
 
 // generateUncompressedSec will generate a formatted uncompressed public key.
 func generateUncompressedSec(pubKey *PublicKey) []byte {
-	// Convert big Ints to big endian bytes := secX, secY.
-	xBytes := pubKey.X.Bytes()
-	yBytes := pubKey.Y.Bytes()
+	// Convert big Ints to 32 byte big endian slices := secX, secY.
+	xBytes := padTo32(pubKey.X.Bytes())
+	yBytes := padTo32(pubKey.Y.Bytes())
 
 	// Created expected for uncompressed, prepend b'x04' to the (secX + secY).
 	sec := append(xBytes, yBytes...)
@@ -87,27 +245,28 @@ func generateUncompressedSec(pubKey *PublicKey) []byte {
 	return sec
 }
 
-// generateUncompressedSec will generate a formatted uncompressed public key.
+// generateCompressedSec will generate a formatted compressed public key.
 func generateCompressedSec(pubKey *PublicKey) []byte {
-	// Convert big Ints to big endian bytes := secX, secY.
-	xBytes := pubKey.X.Bytes()
+	// Convert big Int to a 32 byte big endian slice := secX.
+	xBytes := padTo32(pubKey.X.Bytes())
 
-	// Determine whether to append the 0x02 (even) or odd 0x03 to the sec
-	// public key.
-	yMarker := pubKey.Y.Mod(pubKey.Y, big.NewInt(2))
+	// Prepend 0x02 if Y is even or 0x03 if Y is odd.
+	if pubKey.Y.Bit(0) == 0 {
+		return append([]byte{0x02}, xBytes...)
+	}
 
-	// Declare the sec public key to be returned before prepending the byte
-	// marker.
-	var sec []byte
+	return append([]byte{0x03}, xBytes...)
+}
 
-	// If comparison returns 0, Y is even else Y is odd.
-	if yMarker.Cmp(big.NewInt(0)) == 0 {
-		sec = append([]byte{0x02}, xBytes...)
-	} else {
-		sec = append([]byte{0x03}, xBytes...)
+// padTo32 will left pad b with zeroes to 32 bytes.
+func padTo32(b []byte) []byte {
+	if len(b) >= 32 {
+		return b
 	}
+	padded := make([]byte, 32)
+	copy(padded[32-len(b):], b)
 
-	return sec
+	return padded
 }
 
 // GenerateTestnetAddress will generate a testnet compatible address given the
@@ -122,22 +281,86 @@ func GenerateMainnetAddress(sec []byte) string {
 	return genAddress([]byte{0x00}, sec)
 }
 
-// genTestnet will take a byte prefix and a sec formatted public key and
-// generate a valid testnet address.
+// GenerateTestnetP2SHP2WPKHAddress will generate a testnet P2SH-P2WPKH
+// (nested segwit) address given the compressed SEC.
+func GenerateTestnetP2SHP2WPKHAddress(sec []byte) string {
+	return genAddress([]byte{0xc4}, p2wpkhScript(sec))
+}
+
+// GenerateMainnetP2SHP2WPKHAddress will generate a mainnet P2SH-P2WPKH
+// (nested segwit) address given the compressed SEC.
+func GenerateMainnetP2SHP2WPKHAddress(sec []byte) string {
+	return genAddress([]byte{0x05}, p2wpkhScript(sec))
+}
+
+// GenerateTestnetP2WPKHAddress will generate a testnet P2WPKH (native segwit)
+// address given the compressed SEC.
+func GenerateTestnetP2WPKHAddress(sec []byte) (string, error) {
+	return utils.EncodeSegwitAddress("tb", 0, utils.Hash160(sec))
+}
+
+// GenerateMainnetP2WPKHAddress will generate a mainnet P2WPKH (native segwit)
+// address given the compressed SEC.
+func GenerateMainnetP2WPKHAddress(sec []byte) (string, error) {
+	return utils.EncodeSegwitAddress("bc", 0, utils.Hash160(sec))
+}
+
+// GenerateTestnetP2TRAddress will generate a testnet P2TR (taproot) address
+// given the compressed SEC on the curve c. The key is the BIP 86 internal key,
+// committing to no script path.
+func GenerateTestnetP2TRAddress(c curve.Curve, sec []byte) (string, error) {
+	return genTaprootAddress(c, "tb", sec)
+}
+
+// GenerateMainnetP2TRAddress will generate a mainnet P2TR (taproot) address
+// given the compressed SEC on the curve c. The key is the BIP 86 internal key,
+// committing to no script path.
+func GenerateMainnetP2TRAddress(c curve.Curve, sec []byte) (string, error) {
+	return genTaprootAddress(c, "bc", sec)
+}
+
+// genTaprootAddress will encode the taproot output key of the internal key
+// sec as a version 1 segwit address.
+func genTaprootAddress(c curve.Curve, hrp string, sec []byte) (string, error) {
+	internal, err := ParseSEC(c, sec)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := taprootOutputKey(c, internal)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.EncodeSegwitAddress(hrp, 1, output.XOnly())
+}
+
+// taprootOutputKey returns the output key Q = P + hash_TapTweak(x(P))*G, where
+// P is the internal key with an even y co-ordinate.
+func taprootOutputKey(c curve.Curve, internal *PublicKey) (*PublicKey, error) {
+	p, err := PublicKeyFromXOnly(c, internal.XOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.TweakAdd(c, utils.TaggedHash("TapTweak", p.XOnly()))
+}
+
+// p2wpkhScript will return the witness program script OP_0 <hash160(sec)>,
+// which is the redeem script of a P2SH-P2WPKH address.
+func p2wpkhScript(sec []byte) []byte {
+	return append([]byte{0x00, 0x14}, utils.Hash160(sec)...)
+}
+
+// genAddress will take a byte prefix and a sec formatted public key and
+// generate a Base58Check encoded address.
 func genAddress(prefix, sec []byte) string {
 	// SHA256 -> RIPEMD160
 	hashedSec := utils.Hash160(sec)
 
-	// Prepend the prefix to the hash of the sec public key.
+	// Prepend the prefix to the hash of the sec public key, then Base58
+	// encode it with a checksum.
 	raw := append(prefix, hashedSec...)
 
-	// Double SHA256 the prefix+hash, take the first four bytes as the checksum.
-	checksum := utils.DoubleSHA256(raw)[:4]
-
-	// Base58 encode the appended (prefix+hashed sec pub key) + checksum.
-	raw = append(raw, checksum...)
-
-	address := utils.EncodeBase58(raw)
-
-	return address
+	return utils.EncodeBase58Check(raw)
 }
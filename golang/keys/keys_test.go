@@ -1,7 +1,10 @@
 package keys
 
 import (
+	"encoding/hex"
 	"fmt"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/p256"
 	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
 	"github.com/ccdle12/bitcoin-review/golang/utils"
 	"math/big"
@@ -32,7 +35,7 @@ func TestGenPrivKey(t *testing.T) {
 // a Private and Public Key Pair. The constructor will already check if the
 // Public Key is valid, but for sanities sake we will check it again.
 func TestGenKeyPair(t *testing.T) {
-	keys, err := New()
+	keys, err := New(secp256k1.New())
 	if err != nil {
 		t.Fatalf("test key gen pair failed: %v\n", err)
 	}
@@ -48,6 +51,31 @@ func TestGenKeyPair(t *testing.T) {
 	}
 }
 
+// TestNewBatch will test that every key pair generated in a batch has the
+// Public Key of its Private Key.
+func TestNewBatch(t *testing.T) {
+	curve := secp256k1.New()
+
+	keys, err := NewBatch(curve, 20)
+	if err != nil {
+		t.Fatalf("failed to generate a batch of keys: %v", err)
+	}
+	if len(keys) != 20 {
+		t.Fatalf("expected 20 key pairs, received: %v", len(keys))
+	}
+
+	for _, k := range keys {
+		expected, err := generatePublicKey(curve, k.PrivateKey)
+		if err != nil {
+			t.Fatalf("failed to generate a public key: %v", err)
+		}
+		if !k.PublicKey.Equal(&expected.Point) {
+			t.Fatalf("expected: (%v, %v), received: (%v, %v)",
+				expected.X, expected.Y, k.PublicKey.X, k.PublicKey.Y)
+		}
+	}
+}
+
 // TestUncompressedSec will test that we can generate an uncompressed sec
 // Public Key.
 func TestUncompressedSec(t *testing.T) {
@@ -65,7 +93,7 @@ func TestUncompressedSec(t *testing.T) {
 	}
 
 	// Create a Public Key.
-	publicKey := &PublicKey{X: x, Y: y}
+	publicKey := testPublicKey(t, secp256k1.New(), x, y)
 
 	// Convert big Ints to big endian bytes := secX, secY.
 	xBytes := publicKey.X.Bytes()
@@ -110,7 +138,7 @@ func TestCompressedSec(t *testing.T) {
 	}
 
 	// Create a Public Key.
-	publicKey := &PublicKey{X: x, Y: y}
+	publicKey := testPublicKey(t, secp256k1.New(), x, y)
 
 	// Convert big Ints to big endian bytes := secX, secY.
 	xBytes := publicKey.X.Bytes()
@@ -166,7 +194,7 @@ func TestOddCompressedSec(t *testing.T) {
 	}
 
 	// Create a Public Key.
-	publicKey := &PublicKey{X: x, Y: y}
+	publicKey := testPublicKey(t, secp256k1.New(), x, y)
 	fmt.Printf("SEC: Pubkey y: %v\n", publicKey.Y)
 
 	// Convert big Ints to big endian bytes := secX, secY.
@@ -207,7 +235,7 @@ func TestGenTestnetAddress(t *testing.T) {
 		t.Fatalf("unable to convert y value to big int")
 	}
 
-	publicKey := &PublicKey{X: x, Y: y}
+	publicKey := testPublicKey(t, secp256k1.New(), x, y)
 	secCompressed := generateCompressedSec(publicKey)
 
 	address := GenerateTestnetAddress(secCompressed)
@@ -235,7 +263,7 @@ func TestGenMainnetAddress(t *testing.T) {
 		t.Fatalf("unable to convert y value to big int")
 	}
 
-	publicKey := &PublicKey{X: x, Y: y}
+	publicKey := testPublicKey(t, secp256k1.New(), x, y)
 	secCompressed := generateCompressedSec(publicKey)
 
 	address := GenerateMainnetAddress(secCompressed)
@@ -246,3 +274,251 @@ func TestGenMainnetAddress(t *testing.T) {
 		t.Fatalf("failed to generate correct address, expeted: %v, received: %v", expected, address)
 	}
 }
+
+// TestPrivateKeyFromBytes will test that we can create a Private Key from a
+// secret and that secrets outside of [1, N) are rejected.
+func TestPrivateKeyFromBytes(t *testing.T) {
+	curve := secp256k1.New()
+
+	privateKey, err := PrivateKeyFromBytes(curve, []byte{0x01})
+	if err != nil {
+		t.Fatalf("failed to create a private key: %v", err)
+	}
+
+	if privateKey.Secret().Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("expected secret 1, received: %v", privateKey.Secret())
+	}
+
+	if _, err := PrivateKeyFromBytes(curve, []byte{0x00}); err == nil {
+		t.Fatalf("should have rejected a secret of 0")
+	}
+
+	if _, err := PrivateKeyFromBytes(curve, curve.N.Bytes()); err == nil {
+		t.Fatalf("should have rejected a secret of N")
+	}
+}
+
+// TestGenSegwitAddresses will test that we can generate nested and native
+// segwit addresses for the compressed SEC of the generator point.
+func TestGenSegwitAddresses(t *testing.T) {
+	curve := secp256k1.New()
+	publicKey := testPublicKey(t, curve, curve.Gx, curve.Gy)
+	sec := publicKey.CompressedSEC()
+
+	if address := GenerateMainnetP2SHP2WPKHAddress(sec); address != "3JvL6Ymt8MVWiCNHC7oWU6nLeHNJKLZGLN" {
+		t.Fatalf("failed to generate mainnet p2sh-p2wpkh address, received: %v", address)
+	}
+
+	if address := GenerateTestnetP2SHP2WPKHAddress(sec); address != "2NAUYAHhujozruyzpsFRP63mbrdaU5wnEpN" {
+		t.Fatalf("failed to generate testnet p2sh-p2wpkh address, received: %v", address)
+	}
+
+	address, err := GenerateMainnetP2WPKHAddress(sec)
+	if err != nil || address != "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4" {
+		t.Fatalf("failed to generate mainnet p2wpkh address, received: %v, %v", address, err)
+	}
+
+	address, err = GenerateTestnetP2WPKHAddress(sec)
+	if err != nil || address != "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx" {
+		t.Fatalf("failed to generate testnet p2wpkh address, received: %v, %v", address, err)
+	}
+}
+
+// TestGenTaprootAddresses will test that we can generate taproot addresses
+// for the internal key of the first BIP 86 test vector.
+func TestGenTaprootAddresses(t *testing.T) {
+	curve := secp256k1.New()
+
+	internal, _ := hex.DecodeString("cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	publicKey, err := PublicKeyFromXOnly(curve, internal)
+	if err != nil {
+		t.Fatalf("failed to parse internal key: %v", err)
+	}
+	sec := publicKey.CompressedSEC()
+
+	address, err := GenerateMainnetP2TRAddress(curve, sec)
+	if err != nil || address != "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr" {
+		t.Fatalf("failed to generate mainnet p2tr address, received: %v, %v", address, err)
+	}
+
+	address, err = GenerateTestnetP2TRAddress(curve, sec)
+	if err != nil {
+		t.Fatalf("failed to generate testnet p2tr address: %v", err)
+	}
+
+	version, program, err := utils.DecodeSegwitAddress("tb", address)
+	if err != nil || version != 1 {
+		t.Fatalf("failed to decode testnet p2tr address: %v, %v", address, err)
+	}
+
+	expected := "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"
+	if hex.EncodeToString(program) != expected {
+		t.Fatalf("expected output key: %v, received: %x", expected, program)
+	}
+}
+
+// TestCompressedSecDoesNotMutate will test that generating a compressed SEC
+// leaves the Public Key unchanged.
+func TestCompressedSecDoesNotMutate(t *testing.T) {
+	curve := secp256k1.New()
+	publicKey := testPublicKey(t, curve, curve.Gx, curve.Gy)
+
+	publicKey.CompressedSEC()
+
+	if publicKey.Y.Cmp(curve.Gy) != 0 {
+		t.Fatalf("y was modified to: %v", publicKey.Y)
+	}
+}
+
+// TestXOnly will test that an x-only Public Key round trips to the point with
+// an even y co-ordinate.
+func TestXOnly(t *testing.T) {
+	curve := secp256k1.New()
+
+	// The generator point has an even y.
+	publicKey := testPublicKey(t, curve, curve.Gx, curve.Gy)
+	xOnly := publicKey.XOnly()
+	if len(xOnly) != 32 {
+		t.Fatalf("expected 32 bytes, received: %v", len(xOnly))
+	}
+
+	parsed, err := PublicKeyFromXOnly(curve, xOnly)
+	if err != nil {
+		t.Fatalf("failed to parse x-only public key: %v", err)
+	}
+	if parsed.X.Cmp(curve.Gx) != 0 || parsed.Y.Cmp(curve.Gy) != 0 {
+		t.Fatalf("expected: (%v, %v), received: (%v, %v)", curve.Gx, curve.Gy, parsed.X, parsed.Y)
+	}
+
+	// x = 5 is not on the curve.
+	x := make([]byte, 32)
+	x[31] = 5
+	if _, err := PublicKeyFromXOnly(curve, x); err == nil {
+		t.Fatalf("should have rejected an x not on the curve")
+	}
+}
+
+// TestParseSEC will test that compressed and uncompressed SEC Public Keys
+// round trip for both even and odd y co-ordinates.
+func TestParseSEC(t *testing.T) {
+	curve := secp256k1.New()
+
+	for i := 0; i < 10; i++ {
+		k, err := New(curve)
+		if err != nil {
+			t.Fatalf("failed to generate keys: %v", err)
+		}
+
+		for _, sec := range [][]byte{k.PublicKey.CompressedSEC(), k.PublicKey.UncompressedSEC()} {
+			publicKey, err := ParseSEC(curve, sec)
+			if err != nil {
+				t.Fatalf("failed to parse sec: %x, %v", sec, err)
+			}
+
+			if publicKey.X.Cmp(k.PublicKey.X) != 0 || publicKey.Y.Cmp(k.PublicKey.Y) != 0 {
+				t.Fatalf("expected: (%v, %v), received: (%v, %v)",
+					k.PublicKey.X, k.PublicKey.Y, publicKey.X, publicKey.Y)
+			}
+		}
+	}
+}
+
+// TestParseSECInvalid will test that invalid SEC Public Keys are rejected.
+func TestParseSECInvalid(t *testing.T) {
+	curve := secp256k1.New()
+	publicKey := testPublicKey(t, curve, curve.Gx, curve.Gy)
+
+	compressed := publicKey.CompressedSEC()
+	uncompressed := publicKey.UncompressedSEC()
+
+	hybrid := append([]byte{0x06}, uncompressed[1:]...)
+	badPrefix := append([]byte{0x05}, compressed[1:]...)
+	offCurve := append([]byte{}, uncompressed...)
+	offCurve[64] ^= 0x01
+	notOnCurveX := make([]byte, 33)
+	notOnCurveX[0] = 0x02
+	notOnCurveX[32] = 0x05
+
+	tests := [][]byte{
+		{},
+		compressed[:32],
+		uncompressed[:64],
+		hybrid,
+		badPrefix,
+		offCurve,
+		notOnCurveX,
+	}
+
+	for _, test := range tests {
+		if _, err := ParseSEC(curve, test); err == nil {
+			t.Fatalf("should have rejected sec: %x", test)
+		}
+	}
+}
+
+// testPublicKey will create a Public Key from affine co-ordinates, failing the
+// test if they are not on the curve.
+func testPublicKey(t *testing.T, c *secp256k1.Secp256k1, x, y *big.Int) *PublicKey {
+	publicKey, err := NewPublicKey(c, x, y)
+	if err != nil {
+		t.Fatalf("failed to create a public key: %v", err)
+	}
+
+	return publicKey
+}
+
+// TestNewPublicKey will test that Public Keys must be points on the curve and
+// that the point at infinity is rejected.
+func TestNewPublicKey(t *testing.T) {
+	curve := secp256k1.New()
+
+	if _, err := NewPublicKey(curve, curve.Gx, new(big.Int).Add(curve.Gy, big.NewInt(1))); err == nil {
+		t.Fatalf("should have rejected a point not on the curve")
+	}
+
+	if _, err := NewPublicKey(curve, new(big.Int).Add(curve.Gx, curve.P), curve.Gy); err == nil {
+		t.Fatalf("should have rejected an x not in the field")
+	}
+
+	g := testPublicKey(t, curve, curve.Gx, curve.Gy)
+	if _, err := PublicKeyFromPoint(g.Sub(&g.Point)); err == nil {
+		t.Fatalf("should have rejected the point at infinity")
+	}
+
+	publicKey, err := PublicKeyFromPoint(g.Double())
+	if err != nil {
+		t.Fatalf("failed to create a public key from 2G: %v", err)
+	}
+
+	expected, err := generatePublicKey(curve, &PrivateKey{secret: big.NewInt(2)})
+	if err != nil {
+		t.Fatalf("failed to generate 2G: %v", err)
+	}
+	if !publicKey.Equal(&expected.Point) {
+		t.Fatalf("expected: (%v, %v), received: (%v, %v)", expected.X, expected.Y, publicKey.X, publicKey.Y)
+	}
+}
+
+// TestKeysOnEitherCurve will test that key generation and SEC encoding work
+// unchanged on secp256k1 and P-256.
+func TestKeysOnEitherCurve(t *testing.T) {
+	for _, c := range []curve.Curve{secp256k1.New(), p256.New()} {
+		for i := 0; i < 5; i++ {
+			k, err := New(c)
+			if err != nil {
+				t.Fatalf("%v: failed to generate keys: %v", c.Params().Name, err)
+			}
+
+			for _, sec := range [][]byte{k.PublicKey.CompressedSEC(), k.PublicKey.UncompressedSEC()} {
+				publicKey, err := ParseSEC(c, sec)
+				if err != nil {
+					t.Fatalf("%v: failed to parse sec: %x, %v", c.Params().Name, sec, err)
+				}
+				if !publicKey.Equal(&k.PublicKey.Point) {
+					t.Fatalf("%v: expected: (%v, %v), received: (%v, %v)",
+						c.Params().Name, k.PublicKey.X, k.PublicKey.Y, publicKey.X, publicKey.Y)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package keys
+
+import (
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"math/big"
+)
+
+// parseTweak will parse a 32 byte big-endian tweak, checking that it is less
+// than the order N of the curve c.
+func parseTweak(c curve.Curve, tweak []byte) (*big.Int, error) {
+	if len(tweak) != 32 {
+		return nil, errors.New("tweak must be 32 bytes")
+	}
+
+	t := new(big.Int).SetBytes(tweak)
+	if t.Cmp(c.Params().N) >= 0 {
+		return nil, errors.New("tweak is out of range")
+	}
+
+	return t, nil
+}
+
+// TweakAdd will return the Private Key (secret + tweak) mod N. The Public Key
+// of the result is the Public Key of pk tweaked with PublicKey.TweakAdd.
+func (pk *PrivateKey) TweakAdd(c curve.Curve, tweak []byte) (*PrivateKey, error) {
+	t, err := parseTweak(c, tweak)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := t.Add(t, pk.secret)
+	secret.Mod(secret, c.Params().N)
+	if secret.Sign() == 0 {
+		return nil, errors.New("tweaked private key is zero")
+	}
+
+	return &PrivateKey{secret: secret}, nil
+}
+
+// TweakMul will return the Private Key (secret * tweak) mod N. The Public Key
+// of the result is the Public Key of pk tweaked with PublicKey.TweakMul.
+func (pk *PrivateKey) TweakMul(c curve.Curve, tweak []byte) (*PrivateKey, error) {
+	t, err := parseTweak(c, tweak)
+	if err != nil {
+		return nil, err
+	}
+	if t.Sign() == 0 {
+		return nil, errors.New("tweak must not be zero")
+	}
+
+	secret := t.Mul(t, pk.secret)
+	secret.Mod(secret, c.Params().N)
+
+	return &PrivateKey{secret: secret}, nil
+}
+
+// TweakAdd will return the Public Key pub + tweak*G.
+func (pub *PublicKey) TweakAdd(c curve.Curve, tweak []byte) (*PublicKey, error) {
+	t, err := parseTweak(c, tweak)
+	if err != nil {
+		return nil, err
+	}
+
+	x, y, z := c.ScalarBaseMult(t.Bytes())
+	tG := &curve.JacobianPoint{Curve: c, X: x, Y: y, Z: z}
+	p := &curve.Point{Curve: c, X: pub.X, Y: pub.Y}
+
+	return PublicKeyFromPoint(p.Jacobian().Add(tG).Affine())
+}
+
+// TweakMul will return the Public Key tweak*pub.
+func (pub *PublicKey) TweakMul(c curve.Curve, tweak []byte) (*PublicKey, error) {
+	t, err := parseTweak(c, tweak)
+	if err != nil {
+		return nil, err
+	}
+	if t.Sign() == 0 {
+		return nil, errors.New("tweak must not be zero")
+	}
+
+	x, y, z := c.ScalarMult(pub.X, pub.Y, t.Bytes())
+	p := &curve.JacobianPoint{Curve: c, X: x, Y: y, Z: z}
+
+	return PublicKeyFromPoint(p.Affine())
+}
@@ -0,0 +1,95 @@
+package keys
+
+import (
+	"crypto/rand"
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"math/big"
+	"testing"
+)
+
+// TestTweakConsistency will test that tweaking a Private Key and tweaking its
+// Public Key give the same key pair.
+func TestTweakConsistency(t *testing.T) {
+	curve := secp256k1.New()
+
+	for i := 0; i < 10; i++ {
+		k, err := New(curve)
+		if err != nil {
+			t.Fatalf("failed to generate keys: %v", err)
+		}
+
+		n, _ := rand.Int(rand.Reader, curve.N)
+		tweak := padTo32(n.Bytes())
+
+		priv, err := k.PrivateKey.TweakAdd(curve, tweak)
+		if err != nil {
+			t.Fatalf("failed to tweak add private key: %v", err)
+		}
+		pub, err := k.PublicKey.TweakAdd(curve, tweak)
+		if err != nil {
+			t.Fatalf("failed to tweak add public key: %v", err)
+		}
+		expected, _ := generatePublicKey(curve, priv)
+		if !pub.Equal(&expected.Point) {
+			t.Fatalf("tweak add, expected: (%v, %v), received: (%v, %v)", expected.X, expected.Y, pub.X, pub.Y)
+		}
+
+		priv, err = k.PrivateKey.TweakMul(curve, tweak)
+		if err != nil {
+			t.Fatalf("failed to tweak mul private key: %v", err)
+		}
+		pub, err = k.PublicKey.TweakMul(curve, tweak)
+		if err != nil {
+			t.Fatalf("failed to tweak mul public key: %v", err)
+		}
+		expected, _ = generatePublicKey(curve, priv)
+		if !pub.Equal(&expected.Point) {
+			t.Fatalf("tweak mul, expected: (%v, %v), received: (%v, %v)", expected.X, expected.Y, pub.X, pub.Y)
+		}
+	}
+}
+
+// TestTweakInvalid will test that tweaks out of range, zero multiplications
+// and tweaks that produce zero or the point at infinity are rejected.
+func TestTweakInvalid(t *testing.T) {
+	curve := secp256k1.New()
+	k, _ := New(curve)
+
+	zero := make([]byte, 32)
+	for _, tweak := range [][]byte{curve.N.Bytes(), {0x01}, make([]byte, 33)} {
+		if _, err := k.PrivateKey.TweakAdd(curve, tweak); err == nil {
+			t.Fatalf("private tweak add should have rejected: %x", tweak)
+		}
+		if _, err := k.PublicKey.TweakAdd(curve, tweak); err == nil {
+			t.Fatalf("public tweak add should have rejected: %x", tweak)
+		}
+		if _, err := k.PrivateKey.TweakMul(curve, tweak); err == nil {
+			t.Fatalf("private tweak mul should have rejected: %x", tweak)
+		}
+		if _, err := k.PublicKey.TweakMul(curve, tweak); err == nil {
+			t.Fatalf("public tweak mul should have rejected: %x", tweak)
+		}
+	}
+
+	if _, err := k.PrivateKey.TweakMul(curve, zero); err == nil {
+		t.Fatalf("private tweak mul should have rejected zero")
+	}
+	if _, err := k.PublicKey.TweakMul(curve, zero); err == nil {
+		t.Fatalf("public tweak mul should have rejected zero")
+	}
+
+	// Adding zero leaves the key unchanged.
+	pub, err := k.PublicKey.TweakAdd(curve, zero)
+	if err != nil || !pub.Equal(&k.PublicKey.Point) {
+		t.Fatalf("tweak add of zero should not change the public key: %v", err)
+	}
+
+	// Adding N - secret gives zero and the point at infinity.
+	negated := new(big.Int).Sub(curve.N, k.PrivateKey.secret)
+	if _, err := k.PrivateKey.TweakAdd(curve, padTo32(negated.Bytes())); err == nil {
+		t.Fatalf("private tweak add should have rejected a result of zero")
+	}
+	if _, err := k.PublicKey.TweakAdd(curve, padTo32(negated.Bytes())); err == nil {
+		t.Fatalf("public tweak add should have rejected the point at infinity")
+	}
+}
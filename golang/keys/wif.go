@@ -0,0 +1,95 @@
+package keys
+
+import (
+	"encoding/hex"
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/utils"
+)
+
+const (
+	// wifMainnet and wifTestnet are the version bytes of a WIF Private Key.
+	wifMainnet = 0x80
+	wifTestnet = 0xef
+
+	// wifCompressed is appended to the secret when the Public Key is
+	// serialized compressed.
+	wifCompressed = 0x01
+)
+
+// WIF is a Private Key in Wallet Import Format, along with the network it is
+// for and whether its Public Key is serialized compressed.
+type WIF struct {
+	PrivateKey *PrivateKey
+	Testnet    bool
+	Compressed bool
+}
+
+// Encode will Base58Check encode the WIF as the version byte, the 32 byte
+// secret and a 0x01 suffix if the Public Key is compressed.
+func (w *WIF) Encode() string {
+	version := byte(wifMainnet)
+	if w.Testnet {
+		version = wifTestnet
+	}
+
+	raw := append([]byte{version}, w.PrivateKey.Bytes()...)
+	if w.Compressed {
+		raw = append(raw, wifCompressed)
+	}
+
+	return utils.EncodeBase58Check(raw)
+}
+
+// DecodeWIF will decode a Wallet Import Format Private Key on the curve c,
+// verifying its checksum, version byte and compression flag.
+func DecodeWIF(c curve.Curve, wif string) (*WIF, error) {
+	raw, err := utils.DecodeBase58Check(wif)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &WIF{}
+	switch {
+	case len(raw) == 33:
+	case len(raw) == 34 && raw[33] == wifCompressed:
+		w.Compressed = true
+	default:
+		return nil, errors.New("invalid wif length or compression flag")
+	}
+
+	switch raw[0] {
+	case wifMainnet:
+	case wifTestnet:
+		w.Testnet = true
+	default:
+		return nil, errors.New("invalid wif version byte")
+	}
+
+	w.PrivateKey, err = PrivateKeyFromBytes(c, raw[1:33])
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// PrivateKeyFromHex will create a Private Key from a hex encoded 32 byte
+// secret, checking that it is in the range [1, N) of the curve c.
+func PrivateKeyFromHex(c curve.Curve, s string) (*PrivateKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) != 32 {
+		return nil, errors.New("private key secret must be 32 bytes")
+	}
+
+	return PrivateKeyFromBytes(c, b)
+}
+
+// Bytes returns the Private Key secret as 32 big-endian bytes.
+func (pk *PrivateKey) Bytes() []byte {
+	return padTo32(pk.secret.Bytes())
+}
@@ -0,0 +1,126 @@
+package keys
+
+import (
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"github.com/ccdle12/bitcoin-review/golang/utils"
+	"math/big"
+	"testing"
+)
+
+// TestWIF will test that known secrets encode to and decode from their Wallet
+// Import Format on each network, compressed and uncompressed.
+func TestWIF(t *testing.T) {
+	curve := secp256k1.New()
+
+	tests := []struct {
+		secret     string
+		testnet    bool
+		compressed bool
+		wif        string
+	}{
+		{"1e99423a4ed27608a15a2616a2b0e9e52ced330ac530edcc32c8ffc6a526aedd", false, false, "5J3mBbAH58CpQ3Y5RNJpUKPE62SQ5tfcvU2JpbnkeyhfsYB1Jcn"},
+		{"1e99423a4ed27608a15a2616a2b0e9e52ced330ac530edcc32c8ffc6a526aedd", false, true, "KxFC1jmwwCoACiCAWZ3eXa96mBM6tb3TYzGmf6YwgdGWZgawvrtJ"},
+		{"1e99423a4ed27608a15a2616a2b0e9e52ced330ac530edcc32c8ffc6a526aedd", true, false, "91pPmKypfMGxN73N3iCjLuwBjgo7F4CpGQtFuE9FziSieVTY4jn"},
+		{"1e99423a4ed27608a15a2616a2b0e9e52ced330ac530edcc32c8ffc6a526aedd", true, true, "cNcBUemoNGVRN9fRtxrmtteAPQeWZ399d2REmX1TBjvWpRfNMy91"},
+		{"0000000000000000000000000000000000000000000000000000000000000001", false, false, "5HpHagT65TZzG1PH3CSu63k8DbpvD8s5ip4nEB3kEsreAnchuDf"},
+		{"0000000000000000000000000000000000000000000000000000000000000001", false, true, "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn"},
+		{"0000000000000000000000000000000000000000000000000000000000000001", true, true, "cMahea7zqjxrtgAbB7LSGbcQUr1uX1ojuat9jZodMN87JcbXMTcA"},
+	}
+
+	for _, test := range tests {
+		privateKey, err := PrivateKeyFromHex(curve, test.secret)
+		if err != nil {
+			t.Fatalf("failed to create private key: %v", err)
+		}
+
+		w := &WIF{PrivateKey: privateKey, Testnet: test.testnet, Compressed: test.compressed}
+		if encoded := w.Encode(); encoded != test.wif {
+			t.Fatalf("expected: %v, received: %v", test.wif, encoded)
+		}
+
+		decoded, err := DecodeWIF(curve, test.wif)
+		if err != nil {
+			t.Fatalf("failed to decode wif: %v, %v", test.wif, err)
+		}
+		if decoded.PrivateKey.Secret().Cmp(privateKey.Secret()) != 0 ||
+			decoded.Testnet != test.testnet || decoded.Compressed != test.compressed {
+			t.Fatalf("wif: %v, decoded: (%x, %v, %v)", test.wif,
+				decoded.PrivateKey.Bytes(), decoded.Testnet, decoded.Compressed)
+		}
+	}
+}
+
+// TestDecodeWIFInvalid will test that WIFs with a bad checksum, version byte,
+// compression flag, length or secret are rejected.
+func TestDecodeWIFInvalid(t *testing.T) {
+	curve := secp256k1.New()
+	secret := make([]byte, 32)
+	secret[31] = 1
+
+	raw := func(version byte, secret []byte, suffix ...byte) string {
+		return utils.EncodeBase58Check(append(append([]byte{version}, secret...), suffix...))
+	}
+
+	tests := map[string]string{
+		"checksum":    "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWo",
+		"version":     raw(0x81, secret),
+		"flag":        raw(0x80, secret, 0x02),
+		"short":       raw(0x80, secret[1:]),
+		"long":        raw(0x80, secret, 0x01, 0x01),
+		"zero secret": raw(0x80, make([]byte, 32)),
+		"secret of N": raw(0x80, curve.N.Bytes()),
+		"not base58":  "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoW0",
+		"empty":       "",
+	}
+
+	for name, wif := range tests {
+		if _, err := DecodeWIF(curve, wif); err == nil {
+			t.Fatalf("%v: should have rejected wif: %v", name, wif)
+		}
+	}
+}
+
+// TestPrivateKeyFromHex will test that hex secrets must be 32 bytes and in
+// range.
+func TestPrivateKeyFromHex(t *testing.T) {
+	curve := secp256k1.New()
+
+	for _, s := range []string{"01", "zz", "", "0000000000000000000000000000000000000000000000000000000000000000", curve.N.Text(16)} {
+		if _, err := PrivateKeyFromHex(curve, s); err == nil {
+			t.Fatalf("should have rejected secret: %v", s)
+		}
+	}
+
+	privateKey, err := PrivateKeyFromHex(curve, "1E99423A4ED27608A15A2616A2B0E9E52CED330AC530EDCC32C8FFC6A526AEDD")
+	if err != nil {
+		t.Fatalf("failed to create private key: %v", err)
+	}
+
+	expected, _ := new(big.Int).SetString("1e99423a4ed27608a15a2616a2b0e9e52ced330ac530edcc32c8ffc6a526aedd", 16)
+	if privateKey.Secret().Cmp(expected) != 0 {
+		t.Fatalf("expected: %x, received: %x", expected, privateKey.Secret())
+	}
+}
+
+// TestNewFromPrivateKey will test that a key pair restored from a WIF has the
+// expected addresses.
+func TestNewFromPrivateKey(t *testing.T) {
+	curve := secp256k1.New()
+
+	w, err := DecodeWIF(curve, "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn")
+	if err != nil {
+		t.Fatalf("failed to decode wif: %v", err)
+	}
+
+	k, err := NewFromPrivateKey(curve, w.PrivateKey)
+	if err != nil {
+		t.Fatalf("failed to create keys: %v", err)
+	}
+
+	if address := GenerateMainnetAddress(k.PublicKey.CompressedSEC()); address != "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH" {
+		t.Fatalf("unexpected compressed address: %v", address)
+	}
+	if address := GenerateMainnetAddress(k.PublicKey.UncompressedSEC()); address != "1EHNa6Q4Jz2uvNExL497mE43ikXhwF6kZm" {
+		t.Fatalf("unexpected uncompressed address: %v", address)
+	}
+}
@@ -0,0 +1,323 @@
+package p256
+
+import (
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/utils"
+	"math/big"
+)
+
+const (
+	// NIST P-256, also known as secp256r1 and prime256v1.
+	p  = "FFFFFFFF00000001000000000000000000000000FFFFFFFFFFFFFFFFFFFFFFFF" // Prime Modulo of the Field.
+	a  = "FFFFFFFF00000001000000000000000000000000FFFFFFFFFFFFFFFFFFFFFFFC" // Part of the equation y^2 = x^3 + ax + b, a = -3 mod P.
+	b  = "5AC635D8AA3A93E7B3EBBD55769886BC651D06B0CC53B0F63BCE3C3E27D2604B" // Part of the equation y^2 = x^3 + ax + b.
+	gx = "6B17D1F2E12C4247F8BCE6E563A440F277037D812DEB33A0F4A13945D898C296" // X co-ordinate of the base point (generator point).
+	gy = "4FE342E2FE1A7F9B8EE7EB4A7C0F9E162BCE33576B315ECECBB6406837BF51F5" // Y co-ordinate of the base point (generator point).
+	n  = "FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551" // Order of the base point.
+)
+
+// P256 implements the curve.Curve interface.
+var _ curve.Curve = (*P256)(nil)
+
+// P256 is the NIST P-256 curve, y^2 = x^3 - 3x + b. The arithmetic is done
+// with math/big, which is not constant time.
+type P256 struct {
+	P  *big.Int
+	A  *big.Int
+	B  *big.Int
+	Gx *big.Int
+	Gy *big.Int
+	N  *big.Int
+}
+
+// New is the constructor for the P-256 curve.
+func New() *P256 {
+	P, _ := utils.ConvHexStrToBigInt(p)
+	A, _ := utils.ConvHexStrToBigInt(a)
+	B, _ := utils.ConvHexStrToBigInt(b)
+	Gx, _ := utils.ConvHexStrToBigInt(gx)
+	Gy, _ := utils.ConvHexStrToBigInt(gy)
+	N, _ := utils.ConvHexStrToBigInt(n)
+
+	return &P256{P: P, A: A, B: B, Gx: Gx, Gy: Gy, N: N}
+}
+
+// Params returns the domain parameters of P-256.
+func (c *P256) Params() *curve.Params {
+	return &curve.Params{
+		Name: "P-256",
+		P:    c.P,
+		A:    c.A,
+		B:    c.B,
+		Gx:   c.Gx,
+		Gy:   c.Gy,
+		N:    c.N,
+	}
+}
+
+// IsOnCurve will check whether the x,y co-ordinates satisfy
+// y^2 = x^3 - 3x + b.
+func (c *P256) IsOnCurve(x, y *big.Int) bool {
+	if x.Sign() < 0 || y.Sign() < 0 || x.Cmp(c.P) >= 0 || y.Cmp(c.P) >= 0 {
+		return false
+	}
+
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, c.P)
+
+	return c.polynomial(x).Cmp(y2) == 0
+}
+
+// polynomial returns x^3 - 3x + b mod P.
+func (c *P256) polynomial(x *big.Int) *big.Int {
+	x3 := new(big.Int).Mul(x, x)
+	x3.Mul(x3, x)
+
+	threeX := new(big.Int).Lsh(x, 1)
+	threeX.Add(threeX, x)
+
+	x3.Sub(x3, threeX)
+	x3.Add(x3, c.B)
+
+	return x3.Mod(x3, c.P)
+}
+
+// DecompressY will calculate the y co-ordinate for x by taking the modular
+// square root of x^3 - 3x + b. Since P = 3 mod 4 the root is
+// (x^3 - 3x + b)^((P+1)/4). odd selects which of the two roots is returned.
+func (c *P256) DecompressY(x *big.Int, odd bool) (*big.Int, error) {
+	alpha := c.polynomial(x)
+
+	exp := new(big.Int).Add(c.P, big.NewInt(1))
+	exp.Rsh(exp, 2)
+	y := new(big.Int).Exp(alpha, exp, c.P)
+
+	// alpha may not have a square root, in which case x is not on the curve.
+	y2 := new(big.Int).Mul(y, y)
+	if y2.Mod(y2, c.P).Cmp(alpha) != 0 {
+		return nil, errors.New("x co-ordinate is not on the curve")
+	}
+
+	if (y.Bit(0) == 1) != odd {
+		y.Sub(c.P, y)
+	}
+
+	return y, nil
+}
+
+// JacobianFromAffine converts the point (x, y) in affine co-ordinates to
+// Jacobian co-ordinates by setting z to 1.
+func (c *P256) JacobianFromAffine(x, y *big.Int) (*big.Int, *big.Int, *big.Int) {
+	return new(big.Int).Set(x), new(big.Int).Set(y), big.NewInt(1)
+}
+
+// AffineFromJacobian converts the point (x, y, z) in Jacobian co-ordinates to
+// affine co-ordinates. The point at infinity is returned as (0, 0).
+func (c *P256) AffineFromJacobian(x, y, z *big.Int) (*big.Int, *big.Int) {
+	if z.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+
+	zInv := new(big.Int).ModInverse(z, c.P)
+	zInv2 := new(big.Int).Mul(zInv, zInv)
+
+	ax := new(big.Int).Mul(x, zInv2)
+	ax.Mod(ax, c.P)
+
+	zInv2.Mul(zInv2, zInv)
+	ay := new(big.Int).Mul(y, zInv2)
+	ay.Mod(ay, c.P)
+
+	return ax, ay
+}
+
+// infinity returns the point at infinity in Jacobian co-ordinates.
+func infinity() (*big.Int, *big.Int, *big.Int) {
+	return big.NewInt(1), big.NewInt(1), new(big.Int)
+}
+
+// JacobianDouble doubles the point (x, y, z) in Jacobian co-ordinates using
+// the dbl-2001-b formula, which relies on a = -3.
+//
+// See https://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-3.html.
+func (c *P256) JacobianDouble(x, y, z *big.Int) (*big.Int, *big.Int, *big.Int) {
+	if z.Sign() == 0 || y.Sign() == 0 {
+		return infinity()
+	}
+
+	// delta = z^2, gamma = y^2, beta = x*gamma
+	delta := new(big.Int).Mul(z, z)
+	delta.Mod(delta, c.P)
+	gamma := new(big.Int).Mul(y, y)
+	gamma.Mod(gamma, c.P)
+	beta := new(big.Int).Mul(x, gamma)
+	beta.Mod(beta, c.P)
+
+	// alpha = 3*(x - delta)*(x + delta)
+	alpha := new(big.Int).Sub(x, delta)
+	t := new(big.Int).Add(x, delta)
+	alpha.Mul(alpha, t)
+	alpha.Mul(alpha, big.NewInt(3))
+	alpha.Mod(alpha, c.P)
+
+	// x3 = alpha^2 - 8*beta
+	x3 := new(big.Int).Mul(alpha, alpha)
+	t.Lsh(beta, 3)
+	x3.Sub(x3, t)
+	x3.Mod(x3, c.P)
+
+	// z3 = (y + z)^2 - gamma - delta
+	z3 := new(big.Int).Add(y, z)
+	z3.Mul(z3, z3)
+	z3.Sub(z3, gamma)
+	z3.Sub(z3, delta)
+	z3.Mod(z3, c.P)
+
+	// y3 = alpha*(4*beta - x3) - 8*gamma^2
+	y3 := new(big.Int).Lsh(beta, 2)
+	y3.Sub(y3, x3)
+	y3.Mul(y3, alpha)
+	t.Mul(gamma, gamma)
+	t.Lsh(t, 3)
+	y3.Sub(y3, t)
+	y3.Mod(y3, c.P)
+
+	return x3, y3, z3
+}
+
+// JacobianAdd adds the points (x1, y1, z1) and (x2, y2, z2) in Jacobian
+// co-ordinates using the add-2007-bl formula. A z of 0 represents the point
+// at infinity, adding a point to itself doubles it and adding a point to its
+// negation returns the point at infinity.
+func (c *P256) JacobianAdd(x1, y1, z1, x2, y2, z2 *big.Int) (*big.Int, *big.Int, *big.Int) {
+	if z1.Sign() == 0 {
+		return new(big.Int).Set(x2), new(big.Int).Set(y2), new(big.Int).Set(z2)
+	}
+	if z2.Sign() == 0 {
+		return new(big.Int).Set(x1), new(big.Int).Set(y1), new(big.Int).Set(z1)
+	}
+
+	// u1 = x1*z2^2, u2 = x2*z1^2, s1 = y1*z2^3, s2 = y2*z1^3
+	z1z1 := new(big.Int).Mul(z1, z1)
+	z1z1.Mod(z1z1, c.P)
+	z2z2 := new(big.Int).Mul(z2, z2)
+	z2z2.Mod(z2z2, c.P)
+
+	u1 := new(big.Int).Mul(x1, z2z2)
+	u1.Mod(u1, c.P)
+	u2 := new(big.Int).Mul(x2, z1z1)
+	u2.Mod(u2, c.P)
+
+	s1 := new(big.Int).Mul(y1, z2)
+	s1.Mul(s1, z2z2)
+	s1.Mod(s1, c.P)
+	s2 := new(big.Int).Mul(y2, z1)
+	s2.Mul(s2, z1z1)
+	s2.Mod(s2, c.P)
+
+	// h = u2 - u1, r = 2*(s2 - s1)
+	h := new(big.Int).Sub(u2, u1)
+	h.Mod(h, c.P)
+	r := new(big.Int).Sub(s2, s1)
+	r.Lsh(r, 1)
+	r.Mod(r, c.P)
+
+	if h.Sign() == 0 {
+		if r.Sign() == 0 {
+			return c.JacobianDouble(x1, y1, z1)
+		}
+		return infinity()
+	}
+
+	// i = (2h)^2, j = h*i, v = u1*i
+	i := new(big.Int).Lsh(h, 1)
+	i.Mul(i, i)
+	i.Mod(i, c.P)
+	j := new(big.Int).Mul(h, i)
+	j.Mod(j, c.P)
+	v := new(big.Int).Mul(u1, i)
+	v.Mod(v, c.P)
+
+	// x3 = r^2 - j - 2v
+	x3 := new(big.Int).Mul(r, r)
+	x3.Sub(x3, j)
+	x3.Sub(x3, v)
+	x3.Sub(x3, v)
+	x3.Mod(x3, c.P)
+
+	// y3 = r*(v - x3) - 2*s1*j
+	y3 := new(big.Int).Sub(v, x3)
+	y3.Mul(y3, r)
+	s1.Mul(s1, j)
+	s1.Lsh(s1, 1)
+	y3.Sub(y3, s1)
+	y3.Mod(y3, c.P)
+
+	// z3 = ((z1 + z2)^2 - z1z1 - z2z2)*h
+	z3 := new(big.Int).Add(z1, z2)
+	z3.Mul(z3, z3)
+	z3.Sub(z3, z1z1)
+	z3.Sub(z3, z2z2)
+	z3.Mul(z3, h)
+	z3.Mod(z3, c.P)
+
+	return x3, y3, z3
+}
+
+// ScalarMult returns k*(Bx, By) in Jacobian co-ordinates, where k is a
+// big-endian integer reduced mod N. It uses a Montgomery ladder, so the same
+// sequence of additions and doublings is done for every k of the same length,
+// but math/big is not constant time.
+func (c *P256) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int, *big.Int) {
+	scalar := new(big.Int).SetBytes(k)
+	scalar.Mod(scalar, c.N)
+
+	// r0 = m*B and r1 = (m+1)*B for the bits m of k seen so far.
+	r0x, r0y, r0z := infinity()
+	r1x, r1y, r1z := c.JacobianFromAffine(Bx, By)
+	for i := c.N.BitLen() - 1; i >= 0; i-- {
+		if scalar.Bit(i) == 0 {
+			r1x, r1y, r1z = c.JacobianAdd(r0x, r0y, r0z, r1x, r1y, r1z)
+			r0x, r0y, r0z = c.JacobianDouble(r0x, r0y, r0z)
+		} else {
+			r0x, r0y, r0z = c.JacobianAdd(r0x, r0y, r0z, r1x, r1y, r1z)
+			r1x, r1y, r1z = c.JacobianDouble(r1x, r1y, r1z)
+		}
+	}
+
+	return r0x, r0y, r0z
+}
+
+// ScalarBaseMult returns k*G in Jacobian co-ordinates, where G is the base
+// point and k is a big-endian integer.
+func (c *P256) ScalarBaseMult(k []byte) (*big.Int, *big.Int, *big.Int) {
+	return c.ScalarMult(c.Gx, c.Gy, k)
+}
+
+// MultiScalarMult returns the sum of ks[i]*(xs[i], ys[i]) in Jacobian
+// co-ordinates with Shamir's trick, sharing the doublings between all of the
+// points. It is not constant time and must only be used with public scalars.
+func (c *P256) MultiScalarMult(xs, ys []*big.Int, ks [][]byte) (*big.Int, *big.Int, *big.Int) {
+	scalars := make([]*big.Int, len(ks))
+	length := 0
+	for i, k := range ks {
+		scalars[i] = new(big.Int).SetBytes(k)
+		scalars[i].Mod(scalars[i], c.N)
+		if scalars[i].BitLen() > length {
+			length = scalars[i].BitLen()
+		}
+	}
+
+	x, y, z := infinity()
+	for bit := length - 1; bit >= 0; bit-- {
+		x, y, z = c.JacobianDouble(x, y, z)
+		for i, k := range scalars {
+			if k.Bit(bit) == 1 {
+				x, y, z = c.JacobianAdd(x, y, z, xs[i], ys[i], big.NewInt(1))
+			}
+		}
+	}
+
+	return x, y, z
+}
@@ -0,0 +1,173 @@
+package p256
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// uncompressed returns the uncompressed SEC encoding of (x, y).
+func uncompressed(x, y *big.Int) []byte {
+	b := make([]byte, 65)
+	b[0] = 0x04
+	x.FillBytes(b[1:33])
+	y.FillBytes(b[33:])
+
+	return b
+}
+
+// TestScalarBaseMult will test that public keys match the crypto/ecdh
+// implementation of P-256.
+func TestScalarBaseMult(t *testing.T) {
+	p256 := New()
+
+	for i := 0; i < 20; i++ {
+		key, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("failed to generate a key: %v", err)
+		}
+
+		x, y := p256.AffineFromJacobian(p256.ScalarBaseMult(key.Bytes()))
+		if received := uncompressed(x, y); !bytes.Equal(received, key.PublicKey().Bytes()) {
+			t.Fatalf("expected: %x, received: %x", key.PublicKey().Bytes(), received)
+		}
+		if !p256.IsOnCurve(x, y) {
+			t.Fatalf("(%v, %v) is not on the curve", x, y)
+		}
+	}
+
+	if _, _, z := p256.ScalarBaseMult(p256.N.Bytes()); z.Sign() != 0 {
+		t.Fatalf("N*G expected the point at infinity, z: %v", z)
+	}
+}
+
+// TestScalarMult will test that ScalarMult agrees with ECDH in crypto/ecdh,
+// which multiplies the other party's public key by the private key.
+func TestScalarMult(t *testing.T) {
+	p256 := New()
+
+	for i := 0; i < 10; i++ {
+		a, _ := ecdh.P256().GenerateKey(rand.Reader)
+		b, _ := ecdh.P256().GenerateKey(rand.Reader)
+
+		expected, err := a.ECDH(b.PublicKey())
+		if err != nil {
+			t.Fatalf("failed to compute ecdh: %v", err)
+		}
+
+		pub := b.PublicKey().Bytes()
+		bx, by := new(big.Int).SetBytes(pub[1:33]), new(big.Int).SetBytes(pub[33:])
+		x, _ := p256.AffineFromJacobian(p256.ScalarMult(bx, by, a.Bytes()))
+
+		if received := x.FillBytes(make([]byte, 32)); !bytes.Equal(received, expected) {
+			t.Fatalf("expected: %x, received: %x", expected, received)
+		}
+	}
+}
+
+// TestJacobianEdgeCases will test adding the point at infinity, a point to
+// itself and a point to its negation.
+func TestJacobianEdgeCases(t *testing.T) {
+	p256 := New()
+
+	k, _ := rand.Int(rand.Reader, p256.N)
+	x, y, z := p256.ScalarBaseMult(k.Bytes())
+	ix, iy, iz := infinity()
+
+	ex, ey := p256.AffineFromJacobian(p256.JacobianDouble(x, y, z))
+	ax, ay := p256.AffineFromJacobian(p256.JacobianAdd(x, y, z, x, y, z))
+	if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+		t.Fatalf("P + P expected: (%v, %v), received: (%v, %v)", ex, ey, ax, ay)
+	}
+
+	twoK := new(big.Int).Lsh(k, 1)
+	ex, ey = p256.AffineFromJacobian(p256.ScalarBaseMult(twoK.Mod(twoK, p256.N).Bytes()))
+	if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+		t.Fatalf("2P expected: (%v, %v), received: (%v, %v)", ex, ey, ax, ay)
+	}
+
+	negY := new(big.Int).Sub(p256.P, y)
+	if _, _, z3 := p256.JacobianAdd(x, y, z, x, negY, z); z3.Sign() != 0 {
+		t.Fatalf("P + -P should be the point at infinity, z: %v", z3)
+	}
+
+	ex, ey = p256.AffineFromJacobian(x, y, z)
+	ax, ay = p256.AffineFromJacobian(p256.JacobianAdd(ix, iy, iz, x, y, z))
+	if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+		t.Fatalf("infinity + P expected: (%v, %v), received: (%v, %v)", ex, ey, ax, ay)
+	}
+	ax, ay = p256.AffineFromJacobian(p256.JacobianAdd(x, y, z, ix, iy, iz))
+	if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+		t.Fatalf("P + infinity expected: (%v, %v), received: (%v, %v)", ex, ey, ax, ay)
+	}
+
+	if _, _, z3 := p256.JacobianDouble(ix, iy, iz); z3.Sign() != 0 {
+		t.Fatalf("2*infinity should be the point at infinity, z: %v", z3)
+	}
+}
+
+// TestDecompressY will test that both roots of the generator's x co-ordinate
+// are found and that an x not on the curve is rejected.
+func TestDecompressY(t *testing.T) {
+	p256 := New()
+
+	// Gy is odd.
+	y, err := p256.DecompressY(p256.Gx, true)
+	if err != nil {
+		t.Fatalf("failed to decompress y: %v", err)
+	}
+	if y.Cmp(p256.Gy) != 0 {
+		t.Fatalf("expected y: %v, received: %v", p256.Gy, y)
+	}
+
+	y, err = p256.DecompressY(p256.Gx, false)
+	if err != nil {
+		t.Fatalf("failed to decompress y: %v", err)
+	}
+	if expected := new(big.Int).Sub(p256.P, p256.Gy); y.Cmp(expected) != 0 {
+		t.Fatalf("expected y: %v, received: %v", expected, y)
+	}
+
+	// Half of all x co-ordinates are not on the curve.
+	rejected := false
+	for x := int64(0); x < 10; x++ {
+		if _, err := p256.DecompressY(big.NewInt(x), false); err != nil {
+			rejected = true
+		}
+	}
+	if !rejected {
+		t.Fatalf("should have failed to decompress an x not on the curve")
+	}
+}
+
+// TestMultiScalarMult will test that the multi scalar multiplication is equal
+// to adding the results of separate scalar multiplications.
+func TestMultiScalarMult(t *testing.T) {
+	p256 := New()
+
+	var xs, ys []*big.Int
+	var ks [][]byte
+	ex, ey, ez := infinity()
+
+	for i := 0; i < 5; i++ {
+		p, _ := rand.Int(rand.Reader, p256.N)
+		k, _ := rand.Int(rand.Reader, p256.N)
+
+		x, y := p256.AffineFromJacobian(p256.ScalarBaseMult(p.Bytes()))
+		xs = append(xs, x)
+		ys = append(ys, y)
+		ks = append(ks, k.Bytes())
+
+		jx, jy, jz := p256.ScalarMult(x, y, k.Bytes())
+		ex, ey, ez = p256.JacobianAdd(ex, ey, ez, jx, jy, jz)
+	}
+
+	x, y := p256.AffineFromJacobian(p256.MultiScalarMult(xs, ys, ks))
+	expectedX, expectedY := p256.AffineFromJacobian(ex, ey, ez)
+
+	if x.Cmp(expectedX) != 0 || y.Cmp(expectedY) != 0 {
+		t.Fatalf("expected: (%v, %v), received: (%v, %v)", expectedX, expectedY, x, y)
+	}
+}
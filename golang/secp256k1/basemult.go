@@ -0,0 +1,66 @@
+package secp256k1
+
+import (
+	"math/big"
+	"sync"
+)
+
+var (
+	// baseTable holds baseTable[i][j] = j * 16^i * G, built the first time a
+	// base point multiplication is done.
+	baseTable     *[64][16]jacobianPoint
+	baseTableOnce sync.Once
+)
+
+// generatorTable returns the precomputed multiples of the generator point,
+// building them on first use.
+func generatorTable() *[64][16]jacobianPoint {
+	baseTableOnce.Do(func() {
+		table := new([64][16]jacobianPoint)
+
+		base := jacobianFromBig(mustConvHex(gx), mustConvHex(gy), big.NewInt(1))
+		for i := range table {
+			table[i][0] = infinity()
+			table[i][1] = base
+			for j := 2; j < 16; j++ {
+				table[i][j].add(&table[i][j-1], &base)
+			}
+
+			// The next row starts at 16^(i+1) * G.
+			base.add(&table[i][15], &base)
+		}
+
+		baseTable = table
+	})
+
+	return baseTable
+}
+
+// scalarBaseMult sets pt = k*G using the precomputed generator table. Each
+// 4-bit window of k selects one entry per row, so only additions are needed.
+// The table lookups and additions do not depend on the bits of k.
+func (pt *jacobianPoint) scalarBaseMult(k *scalar) *jacobianPoint {
+	return pt.scalarBaseMultRescaled(k, nil)
+}
+
+// scalarBaseMultRescaled is scalarBaseMult with each selected table entry
+// rescaled by l, randomizing the co-ordinates when l is random. A nil l
+// leaves the entries unchanged.
+func (pt *jacobianPoint) scalarBaseMultRescaled(k *scalar, l *fieldVal) *jacobianPoint {
+	table := generatorTable()
+	limbs := k.limbs()
+
+	acc := infinity()
+	var selected jacobianPoint
+	for i := 0; i < 64; i++ {
+		window := (limbs[i/16] >> uint((i%16)*4)) & 0xf
+		selected.lookup(table[i][:], window)
+		if l != nil {
+			selected.rescale(&selected, l)
+		}
+		acc.add(&acc, &selected)
+	}
+
+	*pt = acc
+	return pt
+}
@@ -0,0 +1,61 @@
+package secp256k1
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+// TestScalarBaseMultTable will test that multiplying with the precomputed
+// generator table agrees with ScalarMult of the generator point.
+func TestScalarBaseMultTable(t *testing.T) {
+	secp256k1 := New()
+
+	for i := 0; i < 20; i++ {
+		k, err := rand.Int(rand.Reader, secp256k1.N)
+		if err != nil {
+			t.Fatalf("failed to generate a scalar: %v", err)
+		}
+
+		ex, ey := secp256k1.AffineFromJacobian(secp256k1.ScalarMult(secp256k1.Gx, secp256k1.Gy, k.Bytes()))
+		ax, ay := secp256k1.AffineFromJacobian(secp256k1.ScalarBaseMult(k.Bytes()))
+
+		if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+			t.Fatalf("k: %v, expected: (%v, %v), received: (%v, %v)", k, ex, ey, ax, ay)
+		}
+	}
+
+	// Zero and N should both give the point at infinity.
+	for _, k := range [][]byte{{0x00}, secp256k1.N.Bytes()} {
+		if _, _, z := secp256k1.ScalarBaseMult(k); z.Sign() != 0 {
+			t.Fatalf("k: %x, expected the point at infinity, z: %v", k, z)
+		}
+	}
+}
+
+// BenchmarkScalarBaseMult will benchmark base point multiplication with the
+// precomputed generator table.
+func BenchmarkScalarBaseMult(b *testing.B) {
+	secp256k1 := New()
+	k, _ := rand.Int(rand.Reader, secp256k1.N)
+
+	// Build the table outside of the timed loop.
+	secp256k1.ScalarBaseMult(k.Bytes())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		secp256k1.ScalarBaseMult(k.Bytes())
+	}
+}
+
+// BenchmarkScalarMultGenerator will benchmark multiplying the generator with
+// the windowed double and add loop, for comparison with
+// BenchmarkScalarBaseMult.
+func BenchmarkScalarMultGenerator(b *testing.B) {
+	secp256k1 := New()
+	k, _ := rand.Int(rand.Reader, secp256k1.N)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		secp256k1.ScalarMult(secp256k1.Gx, secp256k1.Gy, k.Bytes())
+	}
+}
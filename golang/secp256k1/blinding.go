@@ -0,0 +1,88 @@
+package secp256k1
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// NewBlinded is the constructor for a Secp256k1 that blinds the secret scalar
+// multiplications in ScalarMult and ScalarBaseMult, as a defense in depth
+// against side channels. The scalar is split into random shares and the
+// points are given random projective co-ordinates, with fresh randomness from
+// crypto/rand on every call. The results are identical to New, at the cost of
+// a second multiplication.
+func NewBlinded() *Secp256k1 {
+	s := New()
+	s.blinded = true
+
+	return s
+}
+
+// randomScalar returns a uniformly random scalar from crypto/rand.
+func randomScalar() scalar {
+	r, err := rand.Int(rand.Reader, scalarN.mBig())
+	if err != nil {
+		panic("secp256k1: failed to read randomness for blinding")
+	}
+
+	return scalarFromBig(r)
+}
+
+// randomFieldVal returns a uniformly random non-zero field element from
+// crypto/rand.
+func randomFieldVal() fieldVal {
+	max := new(big.Int).Sub(fieldP.mBig(), big.NewInt(1))
+	l, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		panic("secp256k1: failed to read randomness for blinding")
+	}
+
+	return fieldFromBig(l.Add(l, big.NewInt(1)))
+}
+
+// rescale sets pt = (x*l^2, y*l^3, z*l), which is the same point as a with
+// different co-ordinates, and returns pt. l must not be zero.
+func (pt *jacobianPoint) rescale(a *jacobianPoint, l *fieldVal) *jacobianPoint {
+	var l2, l3 fieldVal
+	l2.square(l)
+	l3.mul(&l2, l)
+
+	pt.x.mul(&a.x, &l2)
+	pt.y.mul(&a.y, &l3)
+	pt.z.mul(&a.z, l)
+	return pt
+}
+
+// scalarMultBlinded sets pt = k*a as (k - r)*a + r*a for a random r, so that
+// neither multiplication uses k. Each multiplication starts from a copy of a
+// with random co-ordinates, so the intermediate values are randomized too.
+func (pt *jacobianPoint) scalarMultBlinded(a *jacobianPoint, k *scalar) *jacobianPoint {
+	r := randomScalar()
+	var kr scalar
+	kr.sub(k, &r)
+
+	l1, l2 := randomFieldVal(), randomFieldVal()
+	var a1, a2, q1, q2 jacobianPoint
+	a1.rescale(a, &l1)
+	a2.rescale(a, &l2)
+	q1.scalarMult(&a1, &kr)
+	q2.scalarMult(&a2, &r)
+
+	return pt.add(&q1, &q2)
+}
+
+// scalarBaseMultBlinded sets pt = k*G as (k - r)*G + r*G for a random r. The
+// generator table is fixed, so each table entry is given random co-ordinates
+// as it is selected instead.
+func (pt *jacobianPoint) scalarBaseMultBlinded(k *scalar) *jacobianPoint {
+	r := randomScalar()
+	var kr scalar
+	kr.sub(k, &r)
+
+	l1, l2 := randomFieldVal(), randomFieldVal()
+	var q1, q2 jacobianPoint
+	q1.scalarBaseMultRescaled(&kr, &l1)
+	q2.scalarBaseMultRescaled(&r, &l2)
+
+	return pt.add(&q1, &q2)
+}
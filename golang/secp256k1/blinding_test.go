@@ -0,0 +1,104 @@
+package secp256k1
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// TestBlindedScalarMult will test that the blinded ScalarMult and
+// ScalarBaseMult return the same points as the unblinded path, including the
+// edge case scalars.
+func TestBlindedScalarMult(t *testing.T) {
+	secp256k1 := New()
+	blinded := NewBlinded()
+
+	for _, k := range glvScalars(t, secp256k1) {
+		ex, ey := secp256k1.AffineFromJacobian(secp256k1.ScalarBaseMult(k.Bytes()))
+		ax, ay := blinded.AffineFromJacobian(blinded.ScalarBaseMult(k.Bytes()))
+		if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+			t.Fatalf("ScalarBaseMult k: %x, expected: (%v, %v), received: (%v, %v)", k, ex, ey, ax, ay)
+		}
+
+		x, y, z := randomPoint(t, secp256k1)
+		px, py := secp256k1.AffineFromJacobian(x, y, z)
+
+		ex, ey = secp256k1.AffineFromJacobian(secp256k1.ScalarMult(px, py, k.Bytes()))
+		ax, ay = blinded.AffineFromJacobian(blinded.ScalarMult(px, py, k.Bytes()))
+		if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+			t.Fatalf("ScalarMult k: %x, expected: (%v, %v), received: (%v, %v)", k, ex, ey, ax, ay)
+		}
+	}
+
+	// N*G should still be the point at infinity.
+	if _, _, z := blinded.ScalarBaseMult(blinded.N.Bytes()); z.Sign() != 0 {
+		t.Fatalf("ScalarBaseMult N*G expected the point at infinity, z: %v", z)
+	}
+	if _, _, z := blinded.ScalarMult(blinded.Gx, blinded.Gy, blinded.N.Bytes()); z.Sign() != 0 {
+		t.Fatalf("ScalarMult N*G expected the point at infinity, z: %v", z)
+	}
+}
+
+// TestBlindingRandomizes will test that blinding the same multiplication
+// twice gives different Jacobian co-ordinates for the same affine point.
+func TestBlindingRandomizes(t *testing.T) {
+	blinded := NewBlinded()
+	k, _ := rand.Int(rand.Reader, blinded.N)
+
+	x1, y1, z1 := blinded.ScalarBaseMult(k.Bytes())
+	x2, y2, z2 := blinded.ScalarBaseMult(k.Bytes())
+	if z1.Cmp(z2) == 0 {
+		t.Fatalf("expected different z co-ordinates, received: %v", z1)
+	}
+
+	ax1, ay1 := blinded.AffineFromJacobian(x1, y1, z1)
+	ax2, ay2 := blinded.AffineFromJacobian(x2, y2, z2)
+	if ax1.Cmp(ax2) != 0 || ay1.Cmp(ay2) != 0 {
+		t.Fatalf("expected the same point, received: (%v, %v) and (%v, %v)", ax1, ay1, ax2, ay2)
+	}
+}
+
+// TestRescale will test that rescaling a point leaves its affine
+// co-ordinates unchanged.
+func TestRescale(t *testing.T) {
+	secp256k1 := New()
+
+	for i := 0; i < 10; i++ {
+		a := jacobianFromBig(randomPoint(t, secp256k1))
+		l := randomFieldVal()
+
+		var rescaled jacobianPoint
+		rescaled.rescale(&a, &l)
+		if rescaled.z.equal(&a.z) == 1 {
+			t.Fatalf("expected a different z co-ordinate")
+		}
+
+		ex, ey := a.affine()
+		ax, ay := rescaled.affine()
+		if ex.equal(&ax) != 1 || ey.equal(&ay) != 1 {
+			t.Fatalf("expected: (%v, %v), received: (%v, %v)", ex.big(), ey.big(), ax.big(), ay.big())
+		}
+	}
+
+	// A scalar of 1 is the identity.
+	one := fieldFromInt(1)
+	a := jacobianFromBig(big.NewInt(1), big.NewInt(2), big.NewInt(3))
+	var rescaled jacobianPoint
+	rescaled.rescale(&a, &one)
+	if rescaled != a {
+		t.Fatalf("rescaling by 1 should not change the point")
+	}
+}
+
+// BenchmarkBlindedScalarMult will benchmark the cost of blinding compared to
+// BenchmarkScalarMult.
+func BenchmarkBlindedScalarMult(b *testing.B) {
+	blinded := NewBlinded()
+	k, _ := rand.Int(rand.Reader, blinded.N)
+	x, y := blinded.AffineFromJacobian(blinded.ScalarBaseMult(k.Bytes()))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		blinded.ScalarMult(x, y, k.Bytes())
+	}
+}
@@ -0,0 +1,106 @@
+package secp256k1
+
+import (
+	"github.com/ccdle12/bitcoin-review/golang/utils"
+	"math/big"
+)
+
+// fieldP holds the Montgomery constants for arithmetic modulo P.
+var fieldP = newModulus(mustConvHex(p))
+
+// mustConvHex converts one of the hex string constants to a big Int.
+func mustConvHex(s string) *big.Int {
+	x, err := utils.ConvHexStrToBigInt(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return x
+}
+
+// fieldVal is an element of the field of integers modulo P, stored as fixed
+// 64-bit limbs in Montgomery form. Every operation runs in constant time.
+type fieldVal struct {
+	l [4]uint64
+}
+
+// fieldFromBig will create a fieldVal from a big Int, reducing it mod P.
+func fieldFromBig(x *big.Int) fieldVal {
+	if x.Sign() < 0 || x.BitLen() > 256 {
+		x = new(big.Int).Mod(x, fieldP.mBig())
+	}
+
+	var b [32]byte
+	x.FillBytes(b[:])
+
+	return fieldVal{fieldP.setBytes(&b)}
+}
+
+// fieldFromInt will create a fieldVal from a small integer.
+func fieldFromInt(x uint64) fieldVal {
+	l := [4]uint64{x, 0, 0, 0}
+
+	return fieldVal{fieldP.toMont(&l)}
+}
+
+// big converts the fieldVal to a big Int.
+func (f *fieldVal) big() *big.Int {
+	b := fieldP.bytes(&f.l)
+
+	return new(big.Int).SetBytes(b[:])
+}
+
+// add sets f = a + b and returns f.
+func (f *fieldVal) add(a, b *fieldVal) *fieldVal {
+	f.l = fieldP.add(&a.l, &b.l)
+	return f
+}
+
+// sub sets f = a - b and returns f.
+func (f *fieldVal) sub(a, b *fieldVal) *fieldVal {
+	f.l = fieldP.sub(&a.l, &b.l)
+	return f
+}
+
+// neg sets f = -a and returns f.
+func (f *fieldVal) neg(a *fieldVal) *fieldVal {
+	var zero [4]uint64
+	f.l = fieldP.sub(&zero, &a.l)
+	return f
+}
+
+// mul sets f = a * b and returns f.
+func (f *fieldVal) mul(a, b *fieldVal) *fieldVal {
+	f.l = fieldP.mul(&a.l, &b.l)
+	return f
+}
+
+// square sets f = a^2 and returns f.
+func (f *fieldVal) square(a *fieldVal) *fieldVal {
+	f.l = fieldP.mul(&a.l, &a.l)
+	return f
+}
+
+// inverse sets f = a^-1 and returns f. The inverse of zero is zero.
+func (f *fieldVal) inverse(a *fieldVal) *fieldVal {
+	f.l = fieldP.inverse(&a.l)
+	return f
+}
+
+// isZero returns 1 if f is zero and 0 otherwise.
+func (f *fieldVal) isZero() uint64 {
+	return isZero(&f.l)
+}
+
+// equal returns 1 if f is equal to a and 0 otherwise.
+func (f *fieldVal) equal(a *fieldVal) uint64 {
+	d := [4]uint64{f.l[0] ^ a.l[0], f.l[1] ^ a.l[1], f.l[2] ^ a.l[2], f.l[3] ^ a.l[3]}
+
+	return isZero(&d)
+}
+
+// selectVal sets f to a if flag is 1 and to b if flag is 0, in constant time.
+func (f *fieldVal) selectVal(a, b *fieldVal, flag uint64) *fieldVal {
+	f.l = selectLimbs(&a.l, &b.l, flag)
+	return f
+}
@@ -0,0 +1,274 @@
+package secp256k1
+
+import (
+	"math/bits"
+)
+
+// secp256k1 has an efficiently computable endomorphism: lambda*(x, y) =
+// (beta*x, y), where lambda is a cube root of unity mod N and beta is a cube
+// root of unity mod P. A scalar k can be split into k1 + k2*lambda where k1
+// and k2 are about 128 bits each, so k*P = k1*P + k2*lambda*P can be computed
+// with half the doublings.
+//
+// See https://www.iacr.org/archive/crypto2001/21390189.pdf.
+const (
+	lambdaHex = "5363AD4CC05C30E0A5261C028812645A122E22EA20816678DF02967C1B23BD72"
+	betaHex   = "7AE96A2B657C07106E64479EAC3434E99CF0497512F58995C1396C28719501EE"
+
+	// g1 = round(2^384 * b2 / N) and g2 = round(2^384 * -b1 / N), where
+	// (a1, b1) and (a2, b2) are the short basis of the lattice of
+	// (k1, k2) with k1 + k2*lambda = 0 mod N.
+	g1Hex = "3086D221A7D46BCDE86C90E49284EB153DAA8A1471E8CA7FE893209A45DBB031"
+	g2Hex = "E4437ED6010E88286F547FA90ABFE4C4221208AC9DF506C61571B4AE8AC47F71"
+
+	// -b1 and -b2 mod N.
+	minusB1Hex = "E4437ED6010E88286F547FA90ABFE4C3"
+	minusB2Hex = "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFE8A280AC50774346DD765CDA83DB1562C"
+)
+
+var (
+	lambda  = scalarFromBig(mustConvHex(lambdaHex))
+	beta    = fieldFromBig(mustConvHex(betaHex))
+	g1      = limbsFromBig(mustConvHex(g1Hex))
+	g2      = limbsFromBig(mustConvHex(g2Hex))
+	minusB1 = scalarFromBig(mustConvHex(minusB1Hex))
+	minusB2 = scalarFromBig(mustConvHex(minusB2Hex))
+)
+
+// mulShift384 returns round(a*b / 2^384) for the little-endian limbs a and b,
+// in constant time.
+func mulShift384(a, b *[4]uint64) [4]uint64 {
+	var product [8]uint64
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(a[i], b[j])
+			var c uint64
+			lo, c = bits.Add64(lo, product[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			product[i+j] = lo
+			carry = hi
+		}
+		product[i+4] = carry
+	}
+
+	// Round by adding the highest bit that is shifted out.
+	var result [4]uint64
+	var c uint64
+	result[0], c = bits.Add64(product[6], 0, product[5]>>63)
+	result[1], c = bits.Add64(product[7], 0, c)
+	result[2] = c
+
+	return result
+}
+
+// splitScalar splits k into k1 + k2*lambda = k mod N. k1 and k2 are returned
+// as their absolute values, which are less than 2^128, along with flags that
+// are 1 when they are negative. It runs in constant time.
+func splitScalar(k *scalar) (k1, k2 [4]uint64, neg1, neg2 uint64) {
+	kl := k.limbs()
+
+	// c1 = round(k*b2 / N) and c2 = round(k*-b1 / N).
+	c1l := mulShift384(&kl, &g1)
+	c2l := mulShift384(&kl, &g2)
+	c1 := scalar{scalarN.toMont(&c1l)}
+	c2 := scalar{scalarN.toMont(&c2l)}
+
+	// k2 = -c1*b1 - c2*b2 and k1 = k - k2*lambda.
+	var r1, r2, t scalar
+	r2.mul(&c1, &minusB1)
+	t.mul(&c2, &minusB2)
+	r2.add(&r2, &t)
+	t.mul(&r2, &lambda)
+	r1.sub(k, &t)
+
+	k1, neg1 = scalarAbs(&r1)
+	k2, neg2 = scalarAbs(&r2)
+
+	return k1, k2, neg1, neg2
+}
+
+// scalarAbs returns the limbs of a scalar that is known to be within 2^128 of
+// zero, treating values above N/2 as negative. The flag is 1 when s is
+// negative.
+func scalarAbs(s *scalar) ([4]uint64, uint64) {
+	var negS scalar
+	negS.neg(s)
+
+	l := s.limbs()
+	nl := negS.limbs()
+
+	// A positive value fits in the low two limbs.
+	hi := l[2] | l[3]
+	neg := (hi | -hi) >> 63
+
+	return selectLimbs(&nl, &l, neg), neg
+}
+
+// endomorphism sets pt = lambda*a = (beta*x, y) and returns pt.
+func (pt *jacobianPoint) endomorphism(a *jacobianPoint) *jacobianPoint {
+	pt.x.mul(&a.x, &beta)
+	pt.y = a.y
+	pt.z = a.z
+	return pt
+}
+
+// condNegate sets pt = -a if flag is 1 and pt = a if flag is 0, in constant
+// time.
+func (pt *jacobianPoint) condNegate(a *jacobianPoint, flag uint64) *jacobianPoint {
+	var negY fieldVal
+	negY.neg(&a.y)
+
+	pt.x = a.x
+	pt.y.selectVal(&negY, &a.y, flag)
+	pt.z = a.z
+	return pt
+}
+
+// wnaf returns the width-w non-adjacent form of the little-endian limbs k,
+// least significant digit first. Every non-zero digit is odd with an absolute
+// value less than 2^(w-1), and there is at most one non-zero digit in any w
+// consecutive digits. k must be less than 2^255.
+func wnaf(k [4]uint64, w uint) []int8 {
+	digits := make([]int8, 0, 257)
+	mask := uint64(1)<<w - 1
+
+	for k != [4]uint64{} {
+		var d int64
+		if k[0]&1 == 1 {
+			d = int64(k[0] & mask)
+			if d >= 1<<(w-1) {
+				d -= 1 << w
+			}
+
+			// k = k - d, which leaves the low w bits clear.
+			var c uint64
+			if d > 0 {
+				k[0], c = bits.Sub64(k[0], uint64(d), 0)
+				k[1], c = bits.Sub64(k[1], 0, c)
+				k[2], c = bits.Sub64(k[2], 0, c)
+				k[3], _ = bits.Sub64(k[3], 0, c)
+			} else {
+				k[0], c = bits.Add64(k[0], uint64(-d), 0)
+				k[1], c = bits.Add64(k[1], 0, c)
+				k[2], c = bits.Add64(k[2], 0, c)
+				k[3], _ = bits.Add64(k[3], 0, c)
+			}
+		}
+		digits = append(digits, int8(d))
+
+		k[0] = k[0]>>1 | k[1]<<63
+		k[1] = k[1]>>1 | k[2]<<63
+		k[2] = k[2]>>1 | k[3]<<63
+		k[3] >>= 1
+	}
+
+	return digits
+}
+
+// scalarMult sets pt = k*a. k is split with the endomorphism and both halves
+// use a fixed 4-bit window, so the sequence of operations does not depend on
+// the bits of k.
+func (pt *jacobianPoint) scalarMult(a *jacobianPoint, k *scalar) *jacobianPoint {
+	k1, k2, neg1, neg2 := splitScalar(k)
+
+	// table[i] = i*a, then table1[i] = ±i*a and table2[i] = ±i*lambda*a
+	// following the signs of k1 and k2.
+	var table, table1, table2 [16]jacobianPoint
+	table[0] = infinity()
+	table[1] = *a
+	for i := 2; i < 16; i++ {
+		table[i].add(&table[i-1], a)
+	}
+	for i := range table {
+		table1[i].condNegate(&table[i], neg1)
+		table2[i].endomorphism(&table[i])
+		table2[i].condNegate(&table2[i], neg2)
+	}
+
+	acc := infinity()
+	var selected jacobianPoint
+	for i := 31; i >= 0; i-- {
+		acc.double(&acc)
+		acc.double(&acc)
+		acc.double(&acc)
+		acc.double(&acc)
+
+		shift := uint((i % 16) * 4)
+		selected.lookup(table1[:], (k1[i/16]>>shift)&0xf)
+		acc.add(&acc, &selected)
+		selected.lookup(table2[:], (k2[i/16]>>shift)&0xf)
+		acc.add(&acc, &selected)
+	}
+
+	*pt = acc
+	return pt
+}
+
+// wnafWindow is the window width used for variable time multiplication.
+const wnafWindow = 5
+
+// scalarMultVartime sets pt = k*a, splitting k with the endomorphism and
+// recoding both halves in wNAF. The operations performed depend on the bits
+// of k, so it must only be used with public scalars.
+func (pt *jacobianPoint) scalarMultVartime(a *jacobianPoint, k *scalar) *jacobianPoint {
+	k1, k2, neg1, neg2 := splitScalar(k)
+
+	var p1, p2 jacobianPoint
+	p1.condNegate(a, neg1)
+	p2.endomorphism(a)
+	p2.condNegate(&p2, neg2)
+
+	table1 := oddMultiples(&p1)
+	table2 := oddMultiples(&p2)
+
+	d1 := wnaf(k1, wnafWindow)
+	d2 := wnaf(k2, wnafWindow)
+	length := len(d1)
+	if len(d2) > length {
+		length = len(d2)
+	}
+
+	acc := infinity()
+	for i := length - 1; i >= 0; i-- {
+		acc.double(&acc)
+		if i < len(d1) {
+			acc.addDigit(table1, d1[i])
+		}
+		if i < len(d2) {
+			acc.addDigit(table2, d2[i])
+		}
+	}
+
+	*pt = acc
+	return pt
+}
+
+// oddMultiples returns the table [a, 3a, 5a, ...] used with wNAF digits.
+func oddMultiples(a *jacobianPoint) []jacobianPoint {
+	table := make([]jacobianPoint, 1<<(wnafWindow-2))
+	table[0] = *a
+
+	var twice jacobianPoint
+	twice.double(a)
+	for i := 1; i < len(table); i++ {
+		table[i].addVartime(&table[i-1], &twice)
+	}
+
+	return table
+}
+
+// addDigit adds d*a to pt, where table holds the odd multiples of a and d is
+// a wNAF digit. A zero digit leaves pt unchanged.
+func (pt *jacobianPoint) addDigit(table []jacobianPoint, d int8) {
+	switch {
+	case d > 0:
+		pt.addVartime(pt, &table[d/2])
+	case d < 0:
+		var neg jacobianPoint
+		neg.condNegate(&table[-d/2], 1)
+		pt.addVartime(pt, &neg)
+	}
+}
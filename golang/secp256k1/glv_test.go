@@ -0,0 +1,205 @@
+package secp256k1
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// refScalarMult is a math/big double and add over the reference Jacobian
+// implementation, used to check the GLV multiplications.
+func refScalarMult(s *Secp256k1, x, y *big.Int, k *big.Int) (*big.Int, *big.Int) {
+	rx, ry, rz := big.NewInt(1), big.NewInt(1), new(big.Int)
+	for bit := k.BitLen() - 1; bit >= 0; bit-- {
+		rx, ry, rz = refJacobianDouble(s, rx, ry, rz)
+		if k.Bit(bit) == 1 {
+			rx, ry, rz = refJacobianAdd(s, x, y, big.NewInt(1), rx, ry, rz)
+		}
+	}
+
+	return refAffineFromJacobian(s, rx, ry, rz)
+}
+
+// glvScalars returns edge case scalars for the endomorphism split along with
+// some random ones.
+func glvScalars(t *testing.T, s *Secp256k1) []*big.Int {
+	nMinus1 := new(big.Int).Sub(s.N, big.NewInt(1))
+	halfN := new(big.Int).Rsh(s.N, 1)
+	lambda := mustConvHex(lambdaHex)
+
+	scalars := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		nMinus1,
+		halfN,
+		new(big.Int).Add(halfN, big.NewInt(1)),
+		lambda,
+		new(big.Int).Sub(s.N, lambda),
+		new(big.Int).Lsh(big.NewInt(1), 128),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1)),
+	}
+
+	for i := 0; i < 20; i++ {
+		k, err := rand.Int(rand.Reader, s.N)
+		if err != nil {
+			t.Fatalf("failed to generate a scalar: %v", err)
+		}
+		scalars = append(scalars, k)
+	}
+
+	return scalars
+}
+
+// TestSplitScalar will test that k1 + k2*lambda = k mod N and that both
+// halves are less than 2^128.
+func TestSplitScalar(t *testing.T) {
+	secp256k1 := New()
+	lambda := mustConvHex(lambdaHex)
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+
+	toBig := func(l [4]uint64, neg uint64) *big.Int {
+		b := limbsToBytes(&l)
+		x := new(big.Int).SetBytes(b[:])
+		if neg == 1 {
+			x.Neg(x)
+		}
+		return x
+	}
+
+	for _, k := range glvScalars(t, secp256k1) {
+		s := scalarFromBig(k)
+		k1, k2, neg1, neg2 := splitScalar(&s)
+		b1, b2 := toBig(k1, neg1), toBig(k2, neg2)
+
+		if new(big.Int).Abs(b1).Cmp(limit) >= 0 || new(big.Int).Abs(b2).Cmp(limit) >= 0 {
+			t.Fatalf("k: %x, split is too large: k1: %v, k2: %v", k, b1, b2)
+		}
+
+		sum := new(big.Int).Mul(b2, lambda)
+		sum.Add(sum, b1)
+		sum.Mod(sum, secp256k1.N)
+		if sum.Cmp(k) != 0 {
+			t.Fatalf("k: %x, k1 + k2*lambda: %x", k, sum)
+		}
+	}
+}
+
+// TestWNAF will test that the wNAF digits are odd, bounded, non-adjacent and
+// sum back to the original value.
+func TestWNAF(t *testing.T) {
+	secp256k1 := New()
+
+	for _, k := range glvScalars(t, secp256k1) {
+		digits := wnaf(limbsFromBig(k), wnafWindow)
+
+		sum := new(big.Int)
+		lastNonZero := -wnafWindow
+		for i := len(digits) - 1; i >= 0; i-- {
+			sum.Lsh(sum, 1)
+			sum.Add(sum, big.NewInt(int64(digits[i])))
+		}
+
+		for i, d := range digits {
+			if d == 0 {
+				continue
+			}
+			if d%2 == 0 || d >= 1<<(wnafWindow-1) || d <= -(1<<(wnafWindow-1)) {
+				t.Fatalf("k: %x, invalid digit %v at %v", k, d, i)
+			}
+			if i-lastNonZero < wnafWindow {
+				t.Fatalf("k: %x, digits at %v and %v are too close", k, lastNonZero, i)
+			}
+			lastNonZero = i
+		}
+
+		if sum.Cmp(k) != 0 {
+			t.Fatalf("expected: %x, received: %x", k, sum)
+		}
+	}
+}
+
+// TestGLVDifferential will test that ScalarMult and ScalarMultVartime agree
+// with double and add over the reference implementation for random points.
+func TestGLVDifferential(t *testing.T) {
+	secp256k1 := New()
+
+	for _, k := range glvScalars(t, secp256k1) {
+		x, y, z := randomPoint(t, secp256k1)
+		px, py := refAffineFromJacobian(secp256k1, x, y, z)
+
+		ex, ey := refScalarMult(secp256k1, px, py, k)
+
+		ax, ay := secp256k1.AffineFromJacobian(secp256k1.ScalarMult(px, py, k.Bytes()))
+		if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+			t.Fatalf("ScalarMult k: %x, expected: (%v, %v), received: (%v, %v)", k, ex, ey, ax, ay)
+		}
+
+		ax, ay = secp256k1.AffineFromJacobian(secp256k1.ScalarMultVartime(px, py, k.Bytes()))
+		if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+			t.Fatalf("ScalarMultVartime k: %x, expected: (%v, %v), received: (%v, %v)", k, ex, ey, ax, ay)
+		}
+	}
+
+	// N*P should be the point at infinity.
+	x, y := secp256k1.Gx, secp256k1.Gy
+	if _, _, z := secp256k1.ScalarMult(x, y, secp256k1.N.Bytes()); z.Sign() != 0 {
+		t.Fatalf("ScalarMult N*G expected the point at infinity, z: %v", z)
+	}
+	if _, _, z := secp256k1.ScalarMultVartime(x, y, secp256k1.N.Bytes()); z.Sign() != 0 {
+		t.Fatalf("ScalarMultVartime N*G expected the point at infinity, z: %v", z)
+	}
+}
+
+// TestAddVartime will test that addVartime agrees with add, including adding
+// the point at infinity, a point to itself and a point to its negation.
+func TestAddVartime(t *testing.T) {
+	secp256k1 := New()
+
+	for i := 0; i < 10; i++ {
+		a := jacobianFromBig(randomPoint(t, secp256k1))
+		b := jacobianFromBig(randomPoint(t, secp256k1))
+		var negA jacobianPoint
+		negA.condNegate(&a, 1)
+		inf := infinity()
+
+		cases := [][2]*jacobianPoint{{&a, &b}, {&a, &a}, {&a, &negA}, {&inf, &a}, {&a, &inf}}
+		for j, c := range cases {
+			var expected, received jacobianPoint
+			expected.add(c[0], c[1])
+			received.addVartime(c[0], c[1])
+
+			ex, ey := expected.affine()
+			rx, ry := received.affine()
+			if ex.equal(&rx) != 1 || ey.equal(&ry) != 1 || expected.z.isZero() != received.z.isZero() {
+				t.Fatalf("case %v, expected: (%v, %v), received: (%v, %v)", j, ex.big(), ey.big(), rx.big(), ry.big())
+			}
+		}
+	}
+}
+
+// BenchmarkScalarMult will benchmark the constant-time multiplication of an
+// arbitrary point.
+func BenchmarkScalarMult(b *testing.B) {
+	secp256k1 := New()
+	k, _ := rand.Int(rand.Reader, secp256k1.N)
+	x, y := secp256k1.AffineFromJacobian(secp256k1.ScalarBaseMult(k.Bytes()))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		secp256k1.ScalarMult(x, y, k.Bytes())
+	}
+}
+
+// BenchmarkScalarMultVartime will benchmark the variable time multiplication
+// of an arbitrary point, for comparison with BenchmarkScalarMult.
+func BenchmarkScalarMultVartime(b *testing.B) {
+	secp256k1 := New()
+	k, _ := rand.Int(rand.Reader, secp256k1.N)
+	x, y := secp256k1.AffineFromJacobian(secp256k1.ScalarBaseMult(k.Bytes()))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		secp256k1.ScalarMultVartime(x, y, k.Bytes())
+	}
+}
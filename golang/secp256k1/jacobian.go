@@ -0,0 +1,245 @@
+package secp256k1
+
+import (
+	"math/big"
+)
+
+// jacobianPoint is a point in Jacobian co-ordinates (X/Z^2, Y/Z^3) built on
+// the constant-time fieldVal. A z of zero represents the point at infinity.
+type jacobianPoint struct {
+	x, y, z fieldVal
+}
+
+// infinity returns the point at infinity.
+func infinity() jacobianPoint {
+	return jacobianPoint{x: fieldFromInt(1), y: fieldFromInt(1)}
+}
+
+// jacobianFromBig will create a jacobianPoint from big Int co-ordinates.
+func jacobianFromBig(x, y, z *big.Int) jacobianPoint {
+	return jacobianPoint{fieldFromBig(x), fieldFromBig(y), fieldFromBig(z)}
+}
+
+// big converts the jacobianPoint to big Int co-ordinates.
+func (pt *jacobianPoint) big() (*big.Int, *big.Int, *big.Int) {
+	return pt.x.big(), pt.y.big(), pt.z.big()
+}
+
+// selectPoint sets pt to a if flag is 1 and to b if flag is 0, in constant
+// time.
+func (pt *jacobianPoint) selectPoint(a, b *jacobianPoint, flag uint64) *jacobianPoint {
+	pt.x.selectVal(&a.x, &b.x, flag)
+	pt.y.selectVal(&a.y, &b.y, flag)
+	pt.z.selectVal(&a.z, &b.z, flag)
+	return pt
+}
+
+// double sets pt = 2*a and returns pt.
+func (pt *jacobianPoint) double(a *jacobianPoint) *jacobianPoint {
+	// See http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#doubling-dbl-2009-l
+	var A, B, C, D, E, F, t fieldVal
+
+	A.square(&a.x) // X1^2
+	B.square(&a.y) // Y1^2
+	C.square(&B)   // B^2
+
+	D.add(&a.x, &B) // X1 + B
+	D.square(&D)    // (X1 + B)^2
+	D.sub(&D, &A)   // (X1 + B)^2 - A
+	D.sub(&D, &C)   // (X1 + B)^2 - A - C
+	D.add(&D, &D)   // 2 * ((X1 + B)^2 - A - C)
+
+	E.add(&A, &A) // 2 * A
+	E.add(&E, &A) // 3 * A
+	F.square(&E)  // E^2
+
+	var x3, y3, z3 fieldVal
+	x3.add(&D, &D)  // 2 * D
+	x3.sub(&F, &x3) // F - 2 * D
+
+	y3.sub(&D, &x3) // D - X3
+	y3.mul(&E, &y3) // E * (D - X3)
+	t.add(&C, &C)   // 2 * C
+	t.add(&t, &t)   // 4 * C
+	t.add(&t, &t)   // 8 * C
+	y3.sub(&y3, &t) // E * (D - X3) - 8 * C
+
+	z3.mul(&a.y, &a.z) // Y1 * Z1
+	z3.add(&z3, &z3)   // 2 * Y1 * Z1
+
+	pt.x, pt.y, pt.z = x3, y3, z3
+	return pt
+}
+
+// add sets pt = a + b and returns pt. Adding the point at infinity, adding a
+// point to itself and adding a point to its negation are all handled without
+// branching on the co-ordinates.
+func (pt *jacobianPoint) add(a, b *jacobianPoint) *jacobianPoint {
+	var sum jacobianPoint
+	h, r := sum.addFormula(a, b)
+
+	// If both points are the same the formula breaks down, use doubling.
+	var dbl jacobianPoint
+	dbl.double(a)
+	same := h.isZero() & r.isZero()
+	sum.selectPoint(&dbl, &sum, same)
+
+	// Adding the point at infinity returns the other point.
+	sum.selectPoint(b, &sum, a.z.isZero())
+	sum.selectPoint(a, &sum, b.z.isZero())
+
+	*pt = sum
+	return pt
+}
+
+// addVartime sets pt = a + b and returns pt. It branches on the
+// co-ordinates to handle the special cases, so it must only be used with
+// public values.
+func (pt *jacobianPoint) addVartime(a, b *jacobianPoint) *jacobianPoint {
+	if a.z.isZero() == 1 {
+		*pt = *b
+		return pt
+	}
+	if b.z.isZero() == 1 {
+		*pt = *a
+		return pt
+	}
+
+	var sum jacobianPoint
+	h, r := sum.addFormula(a, b)
+	if h.isZero() == 1 && r.isZero() == 1 {
+		return pt.double(a)
+	}
+
+	*pt = sum
+	return pt
+}
+
+// addFormula sets pt = a + b using the addition formula, which is only correct
+// for distinct points that are not the point at infinity. It returns h and r,
+// which are both zero when a and b are the same point. pt must not alias a or
+// b.
+func (pt *jacobianPoint) addFormula(a, b *jacobianPoint) (fieldVal, fieldVal) {
+	// See http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-add-2007-bl
+	var z1z1, z2z2, u1, u2, s1, s2, h, i, j, r, v fieldVal
+
+	z1z1.square(&a.z)
+	z2z2.square(&b.z)
+
+	u1.mul(&a.x, &z2z2)
+	u2.mul(&b.x, &z1z1)
+
+	s1.mul(&a.y, &b.z)
+	s1.mul(&s1, &z2z2)
+	s2.mul(&b.y, &a.z)
+	s2.mul(&s2, &z1z1)
+
+	h.sub(&u2, &u1)
+	i.add(&h, &h)
+	i.square(&i)
+	j.mul(&h, &i)
+
+	r.sub(&s2, &s1)
+	r.add(&r, &r)
+	v.mul(&u1, &i)
+
+	// X3 = r^2 - J - 2*V
+	pt.x.square(&r)
+	pt.x.sub(&pt.x, &j)
+	pt.x.sub(&pt.x, &v)
+	pt.x.sub(&pt.x, &v)
+
+	// Y3 = r*(V - X3) - 2*S1*J
+	pt.y.sub(&v, &pt.x)
+	pt.y.mul(&r, &pt.y)
+	s1.mul(&s1, &j)
+	s1.add(&s1, &s1)
+	pt.y.sub(&pt.y, &s1)
+
+	// Z3 = ((Z1 + Z2)^2 - Z1Z1 - Z2Z2) * H, which is zero when the points
+	// are each other's negation.
+	pt.z.add(&a.z, &b.z)
+	pt.z.square(&pt.z)
+	pt.z.sub(&pt.z, &z1z1)
+	pt.z.sub(&pt.z, &z2z2)
+	pt.z.mul(&pt.z, &h)
+
+	return h, r
+}
+
+// affine converts the point to affine co-ordinates. The point at infinity
+// is returned as (0, 0).
+func (pt *jacobianPoint) affine() (fieldVal, fieldVal) {
+	var zInv, zInv2, x, y fieldVal
+	zInv.inverse(&pt.z)
+	zInv2.square(&zInv)
+
+	x.mul(&pt.x, &zInv2)
+	zInv2.mul(&zInv2, &zInv)
+	y.mul(&pt.y, &zInv2)
+
+	return x, y
+}
+
+// batchAffine converts every point to affine co-ordinates with a single field
+// inversion using Montgomery's trick: invert the product of all the z
+// co-ordinates, then peel off each inverse by multiplying with the products
+// either side of it. The point at infinity is returned as (0, 0), as with
+// affine.
+func batchAffine(points []jacobianPoint) ([]fieldVal, []fieldVal) {
+	xs := make([]fieldVal, len(points))
+	ys := make([]fieldVal, len(points))
+	if len(points) == 0 {
+		return xs, ys
+	}
+
+	// A z of 0 is replaced by 1 so that it does not zero the product.
+	one := fieldFromInt(1)
+	zs := make([]fieldVal, len(points))
+	for i := range points {
+		zs[i].selectVal(&one, &points[i].z, points[i].z.isZero())
+	}
+
+	// prefix[i] = z[0] * ... * z[i]
+	prefix := make([]fieldVal, len(points))
+	prefix[0] = zs[0]
+	for i := 1; i < len(points); i++ {
+		prefix[i].mul(&prefix[i-1], &zs[i])
+	}
+
+	// inv starts as (z[0] * ... * z[n-1])^-1 and each step removes z[i].
+	var inv, zero fieldVal
+	inv.inverse(&prefix[len(points)-1])
+	for i := len(points) - 1; i >= 0; i-- {
+		zInv := inv
+		if i > 0 {
+			zInv.mul(&inv, &prefix[i-1])
+		}
+		inv.mul(&inv, &zs[i])
+
+		var zInv2 fieldVal
+		zInv2.square(&zInv)
+		xs[i].mul(&points[i].x, &zInv2)
+		zInv2.mul(&zInv2, &zInv)
+		ys[i].mul(&points[i].y, &zInv2)
+
+		isInfinity := points[i].z.isZero()
+		xs[i].selectVal(&zero, &xs[i], isInfinity)
+		ys[i].selectVal(&zero, &ys[i], isInfinity)
+	}
+
+	return xs, ys
+}
+
+// lookup sets pt to table[index] without the memory access pattern depending
+// on index.
+func (pt *jacobianPoint) lookup(table []jacobianPoint, index uint64) *jacobianPoint {
+	*pt = infinity()
+	for i := range table {
+		// eq is 1 when i == index.
+		d := uint64(i) ^ index
+		eq := ((d | -d) >> 63) ^ 1
+		pt.selectPoint(&table[i], pt, eq)
+	}
+	return pt
+}
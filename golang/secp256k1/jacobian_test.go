@@ -0,0 +1,299 @@
+package secp256k1
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// refJacobianAdd is the math/big implementation of JacobianAdd, kept as a
+// reference for differential tests. It adds the points (x1, y1, z1) and
+// (x2, y2, z2) in Jacobian co-ordinates.
+func refJacobianAdd(s *Secp256k1, x1, y1, z1, x2, y2, z2 *big.Int) (*big.Int, *big.Int, *big.Int) {
+	// Adding the point at infinity returns the other point.
+	if z1.Sign() == 0 {
+		return new(big.Int).Set(x2), new(big.Int).Set(y2), new(big.Int).Set(z2)
+	}
+	if z2.Sign() == 0 {
+		return new(big.Int).Set(x1), new(big.Int).Set(y1), new(big.Int).Set(z1)
+	}
+
+	// See http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-add-2007-bl
+	z1z1 := new(big.Int).Mul(z1, z1)
+	z1z1.Mod(z1z1, s.P)
+	z2z2 := new(big.Int).Mul(z2, z2)
+	z2z2.Mod(z2z2, s.P)
+
+	u1 := new(big.Int).Mul(x1, z2z2)
+	u1.Mod(u1, s.P)
+
+	u2 := new(big.Int).Mul(x2, z1z1)
+	u2.Mod(u2, s.P)
+
+	h := new(big.Int).Sub(u2, u1)
+	// Check if h is less than 0.
+	if h.Sign() == -1 {
+		h.Add(h, s.P)
+	}
+	i := new(big.Int).Lsh(h, 1)
+	i.Mul(i, i)
+	j := new(big.Int).Mul(h, i)
+
+	s1 := new(big.Int).Mul(y1, z2)
+	s1.Mul(s1, z2z2)
+	s1.Mod(s1, s.P)
+	s2 := new(big.Int).Mul(y2, z1)
+	s2.Mul(s2, z1z1)
+	s2.Mod(s2, s.P)
+	r := new(big.Int).Sub(s2, s1)
+	// Check if r is less than 0.
+	if r.Sign() == -1 {
+		r.Add(r, s.P)
+	}
+
+	// If both points share the same x co-ordinate they are either the same
+	// point, which needs doubling, or each other's negation, which sums to
+	// the point at infinity.
+	if h.Sign() == 0 {
+		if r.Sign() == 0 {
+			return refJacobianDouble(s, x1, y1, z1)
+		}
+		return big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	}
+	r.Lsh(r, 1)
+	v := new(big.Int).Mul(u1, i)
+
+	x3 := new(big.Int).Set(r)
+	x3.Mul(x3, x3)
+	x3.Sub(x3, j)
+	x3.Sub(x3, v)
+	x3.Sub(x3, v)
+	x3.Mod(x3, s.P)
+
+	y3 := new(big.Int).Set(r)
+	v.Sub(v, x3)
+	y3.Mul(y3, v)
+	s1.Mul(s1, j)
+	s1.Lsh(s1, 1)
+	y3.Sub(y3, s1)
+	y3.Mod(y3, s.P)
+
+	z3 := new(big.Int).Add(z1, z2)
+	z3.Mul(z3, z3)
+	z3.Sub(z3, z1z1)
+	if z3.Sign() == -1 {
+		z3.Add(z3, s.P)
+	}
+	z3.Sub(z3, z2z2)
+	if z3.Sign() == -1 {
+		z3.Add(z3, s.P)
+	}
+	z3.Mul(z3, h)
+	z3.Mod(z3, s.P)
+
+	return x3, y3, z3
+
+}
+
+// refJacobianDouble is the math/big reference implementation of
+// JacobianDouble. It doubles the point (x, y, z) in Jacobian co-ordinates.
+func refJacobianDouble(s *Secp256k1, x, y, z *big.Int) (*big.Int, *big.Int, *big.Int) {
+	// See http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#doubling-dbl-2009-l
+	a := new(big.Int).Mul(x, x) // X1^2
+	b := new(big.Int).Mul(y, y) // Y1^2
+	c := new(big.Int).Mul(b, b) // B^2
+
+	d := new(big.Int).Add(x, b) //X1 + B
+	d.Mul(d, d)                 // (X1 +B)^2
+	d.Sub(d, a)                 //  (X1 + B)^2 - A
+	d.Sub(d, c)                 //  (X1 + B)^2 - C
+	d.Mul(d, big.NewInt(2))     // 2 * ((X1 + B)^2 - A - C)
+
+	e := new(big.Int).Mul(big.NewInt(3), a) // 3 * A
+	f := new(big.Int).Mul(e, e)             // E^2
+
+	x3 := new(big.Int).Mul(big.NewInt(2), d) // 2 * D
+	x3.Sub(f, x3)                            // F - 2 * D
+	x3.Mod(x3, s.P)
+
+	y3 := new(big.Int).Sub(d, x3)                  // D - X3
+	y3.Mul(e, y3)                                  // E * (D-X3)
+	y3.Sub(y3, new(big.Int).Mul(big.NewInt(8), c)) // E * (D-X3) - 8 * C
+	y3.Mod(y3, s.P)
+
+	z3 := new(big.Int).Mul(y, z) // Y1 * Z1
+	z3.Mul(big.NewInt(2), z3)
+	z3.Mod(z3, s.P)
+
+	return x3, y3, z3
+}
+
+// refAffineFromJacobian is the math/big reference implementation of
+// AffineFromJacobian. The point at infinity is returned as (0, 0).
+func refAffineFromJacobian(s *Secp256k1, x, y, z *big.Int) (*big.Int, *big.Int) {
+	if z.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+
+	zinv := new(big.Int).ModInverse(z, s.P)
+	zinvsq := new(big.Int).Mul(zinv, zinv)
+
+	xOut := new(big.Int).Mul(x, zinvsq)
+	xOut.Mod(xOut, s.P)
+
+	zinvsq.Mul(zinvsq, zinv)
+
+	yOut := new(big.Int).Mul(y, zinvsq)
+	yOut.Mod(yOut, s.P)
+
+	return xOut, yOut
+}
+
+// randomPoint returns a random point in Jacobian co-ordinates with a random
+// z, computed with the reference implementation.
+func randomPoint(t testing.TB, s *Secp256k1) (*big.Int, *big.Int, *big.Int) {
+	k, err := rand.Int(rand.Reader, s.N)
+	if err != nil {
+		t.Fatalf("failed to generate a scalar: %v", err)
+	}
+	x, y := s.AffineFromJacobian(s.ScalarBaseMult(k.Bytes()))
+
+	// Scale by a random z: (x*z^2, y*z^3, z).
+	z, err := rand.Int(rand.Reader, s.P)
+	if err != nil {
+		t.Fatalf("failed to generate z: %v", err)
+	}
+	z2 := new(big.Int).Mul(z, z)
+	z3 := new(big.Int).Mul(z2, z)
+	x.Mul(x, z2).Mod(x, s.P)
+	y.Mul(y, z3).Mod(y, s.P)
+
+	return x, y, z
+}
+
+// TestJacobianDifferential will test that the constant-time JacobianAdd,
+// JacobianDouble and AffineFromJacobian agree with the math/big reference
+// implementations.
+func TestJacobianDifferential(t *testing.T) {
+	secp256k1 := New()
+
+	for i := 0; i < 50; i++ {
+		x1, y1, z1 := randomPoint(t, secp256k1)
+		x2, y2, z2 := randomPoint(t, secp256k1)
+
+		sx, sy, sz := refJacobianAdd(secp256k1, x1, y1, z1, x2, y2, z2)
+		ex, ey := refAffineFromJacobian(secp256k1, sx, sy, sz)
+		ax, ay := secp256k1.AffineFromJacobian(secp256k1.JacobianAdd(x1, y1, z1, x2, y2, z2))
+		if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+			t.Fatalf("add expected: (%v, %v), received: (%v, %v)", ex, ey, ax, ay)
+		}
+
+		dx, dy, dz := refJacobianDouble(secp256k1, x1, y1, z1)
+		ex, ey = refAffineFromJacobian(secp256k1, dx, dy, dz)
+		ax, ay = secp256k1.AffineFromJacobian(secp256k1.JacobianDouble(x1, y1, z1))
+		if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+			t.Fatalf("double expected: (%v, %v), received: (%v, %v)", ex, ey, ax, ay)
+		}
+
+		// Adding a point to itself should double it.
+		ax, ay = secp256k1.AffineFromJacobian(secp256k1.JacobianAdd(x1, y1, z1, x1, y1, z1))
+		if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+			t.Fatalf("self add expected: (%v, %v), received: (%v, %v)", ex, ey, ax, ay)
+		}
+
+		// Adding a point to its negation should be the point at infinity.
+		negY := new(big.Int).Sub(secp256k1.P, y1)
+		_, _, z := secp256k1.JacobianAdd(x1, y1, z1, x1, negY, z1)
+		if z.Sign() != 0 {
+			t.Fatalf("P + -P should be the point at infinity, z: %v", z)
+		}
+
+		// Adding the point at infinity should return the point.
+		ax, ay = secp256k1.AffineFromJacobian(secp256k1.JacobianAdd(big.NewInt(1), big.NewInt(1), new(big.Int), x1, y1, z1))
+		ex, ey = refAffineFromJacobian(secp256k1, x1, y1, z1)
+		if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+			t.Fatalf("infinity add expected: (%v, %v), received: (%v, %v)", ex, ey, ax, ay)
+		}
+	}
+}
+
+// TestScalarMultDifferential will test that the constant-time ScalarMult
+// agrees with double and add over the reference implementation.
+func TestScalarMultDifferential(t *testing.T) {
+	secp256k1 := New()
+
+	for i := 0; i < 10; i++ {
+		k, err := rand.Int(rand.Reader, secp256k1.N)
+		if err != nil {
+			t.Fatalf("failed to generate a scalar: %v", err)
+		}
+
+		x, y, z := big.NewInt(1), big.NewInt(1), new(big.Int)
+		for bit := k.BitLen() - 1; bit >= 0; bit-- {
+			x, y, z = refJacobianDouble(secp256k1, x, y, z)
+			if k.Bit(bit) == 1 {
+				x, y, z = refJacobianAdd(secp256k1, secp256k1.Gx, secp256k1.Gy, big.NewInt(1), x, y, z)
+			}
+		}
+		ex, ey := refAffineFromJacobian(secp256k1, x, y, z)
+
+		ax, ay := secp256k1.AffineFromJacobian(secp256k1.ScalarBaseMult(k.Bytes()))
+		if ex.Cmp(ax) != 0 || ey.Cmp(ay) != 0 {
+			t.Fatalf("k: %v, expected: (%v, %v), received: (%v, %v)", k, ex, ey, ax, ay)
+		}
+	}
+}
+
+// TestBatchAffineFromJacobian will test that the batch conversion agrees with
+// converting each point separately, including points at infinity at the
+// start, middle and end.
+func TestBatchAffineFromJacobian(t *testing.T) {
+	secp256k1 := New()
+
+	for _, n := range []int{0, 1, 2, 17} {
+		var xs, ys, zs []*big.Int
+		for i := 0; i < n; i++ {
+			x, y, z := randomPoint(t, secp256k1)
+			if i == 0 || i == n/2 || i == n-1 {
+				z = new(big.Int)
+			}
+			xs, ys, zs = append(xs, x), append(ys, y), append(zs, z)
+		}
+
+		ax, ay := secp256k1.BatchAffineFromJacobian(xs, ys, zs)
+		if len(ax) != n || len(ay) != n {
+			t.Fatalf("n: %v, expected %v points, received: %v", n, n, len(ax))
+		}
+
+		for i := range xs {
+			ex, ey := secp256k1.AffineFromJacobian(xs[i], ys[i], zs[i])
+			if ex.Cmp(ax[i]) != 0 || ey.Cmp(ay[i]) != 0 {
+				t.Fatalf("n: %v, point %v, expected: (%v, %v), received: (%v, %v)", n, i, ex, ey, ax[i], ay[i])
+			}
+		}
+	}
+}
+
+// BenchmarkAffineFromJacobian will benchmark converting 1000 points one at a
+// time against the batch conversion.
+func BenchmarkAffineFromJacobian(b *testing.B) {
+	secp256k1 := New()
+
+	points := make([]jacobianPoint, 1000)
+	for i := range points {
+		points[i] = jacobianFromBig(randomPoint(b, secp256k1))
+	}
+
+	b.Run("single", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range points {
+				points[j].affine()
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			batchAffine(points)
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package secp256k1
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// modulus holds the constants needed for constant-time Montgomery arithmetic
+// modulo a 256-bit odd prime m. Values are stored as four little-endian
+// 64-bit limbs in Montgomery form, a*R mod m where R = 2^256.
+type modulus struct {
+	m   [4]uint64 // The modulus.
+	inv uint64    // -m^-1 mod 2^64.
+	r2  [4]uint64 // R^2 mod m, used to convert into Montgomery form.
+	one [4]uint64 // R mod m, the Montgomery form of 1.
+	exp [4]uint64 // m-2, the exponent used for inversion.
+}
+
+// newModulus will create the Montgomery constants for the prime m.
+func newModulus(m *big.Int) *modulus {
+	mod := &modulus{m: limbsFromBig(m)}
+
+	// Newton's method for the inverse of m mod 2^64, each step doubles the
+	// number of correct bits.
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - mod.m[0]*inv
+	}
+	mod.inv = -inv
+
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	mod.one = limbsFromBig(new(big.Int).Mod(r, m))
+	r2 := new(big.Int).Mul(r, r)
+	mod.r2 = limbsFromBig(r2.Mod(r2, m))
+	mod.exp = limbsFromBig(new(big.Int).Sub(m, big.NewInt(2)))
+
+	return mod
+}
+
+// mBig returns the modulus as a big Int.
+func (mod *modulus) mBig() *big.Int {
+	b := limbsToBytes(&mod.m)
+
+	return new(big.Int).SetBytes(b[:])
+}
+
+// limbsFromBig converts a non-negative big Int below 2^256 to limbs.
+func limbsFromBig(x *big.Int) [4]uint64 {
+	var b [32]byte
+	x.FillBytes(b[:])
+
+	return limbsFromBytes(&b)
+}
+
+// limbsFromBytes converts 32 big-endian bytes to little-endian limbs.
+func limbsFromBytes(b *[32]byte) [4]uint64 {
+	var l [4]uint64
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			l[3-i] = l[3-i]<<8 | uint64(b[i*8+j])
+		}
+	}
+
+	return l
+}
+
+// limbsToBytes converts little-endian limbs to 32 big-endian bytes.
+func limbsToBytes(l *[4]uint64) [32]byte {
+	var b [32]byte
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			b[i*8+j] = byte(l[3-i] >> uint(56-8*j))
+		}
+	}
+
+	return b
+}
+
+// reduce will return the limbs of a, which must be below 2m, reduced modulo
+// m, where carry is a 257th bit of a. It runs in constant time.
+func (mod *modulus) reduce(a *[4]uint64, carry uint64) [4]uint64 {
+	var d [4]uint64
+	var borrow uint64
+	d[0], borrow = bits.Sub64(a[0], mod.m[0], 0)
+	d[1], borrow = bits.Sub64(a[1], mod.m[1], borrow)
+	d[2], borrow = bits.Sub64(a[2], mod.m[2], borrow)
+	d[3], borrow = bits.Sub64(a[3], mod.m[3], borrow)
+	_, borrow = bits.Sub64(carry, 0, borrow)
+
+	// If the subtraction borrowed then a was already below m, keep a.
+	return selectLimbs(a, &d, borrow)
+}
+
+// selectLimbs returns a if flag is 1 and b if flag is 0, in constant time.
+func selectLimbs(a, b *[4]uint64, flag uint64) [4]uint64 {
+	mask := -flag
+
+	return [4]uint64{
+		a[0]&mask | b[0]&^mask,
+		a[1]&mask | b[1]&^mask,
+		a[2]&mask | b[2]&^mask,
+		a[3]&mask | b[3]&^mask,
+	}
+}
+
+// add returns a + b mod m.
+func (mod *modulus) add(a, b *[4]uint64) [4]uint64 {
+	var t [4]uint64
+	var carry uint64
+	t[0], carry = bits.Add64(a[0], b[0], 0)
+	t[1], carry = bits.Add64(a[1], b[1], carry)
+	t[2], carry = bits.Add64(a[2], b[2], carry)
+	t[3], carry = bits.Add64(a[3], b[3], carry)
+
+	return mod.reduce(&t, carry)
+}
+
+// sub returns a - b mod m.
+func (mod *modulus) sub(a, b *[4]uint64) [4]uint64 {
+	var t [4]uint64
+	var borrow uint64
+	t[0], borrow = bits.Sub64(a[0], b[0], 0)
+	t[1], borrow = bits.Sub64(a[1], b[1], borrow)
+	t[2], borrow = bits.Sub64(a[2], b[2], borrow)
+	t[3], borrow = bits.Sub64(a[3], b[3], borrow)
+
+	// Add m back if the subtraction borrowed.
+	mask := -borrow
+	var carry uint64
+	t[0], carry = bits.Add64(t[0], mod.m[0]&mask, 0)
+	t[1], carry = bits.Add64(t[1], mod.m[1]&mask, carry)
+	t[2], carry = bits.Add64(t[2], mod.m[2]&mask, carry)
+	t[3], _ = bits.Add64(t[3], mod.m[3]&mask, carry)
+
+	return t
+}
+
+// mul returns the Montgomery product a * b * R^-1 mod m, using the coarsely
+// integrated operand scanning (CIOS) method.
+func (mod *modulus) mul(a, b *[4]uint64) [4]uint64 {
+	var t [6]uint64
+
+	for i := 0; i < 4; i++ {
+		// t += a * b[i]
+		var c uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(a[j], b[i])
+			var cc uint64
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j] = lo
+			c = hi
+		}
+		var cc uint64
+		t[4], cc = bits.Add64(t[4], c, 0)
+		t[5] = cc
+
+		// t = (t + u*m) / 2^64, where u makes the lowest limb zero.
+		u := t[0] * mod.inv
+		hi, lo := bits.Mul64(u, mod.m[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(u, mod.m[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1] = lo
+			c = hi
+		}
+		t[3], cc = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + cc
+	}
+
+	r := [4]uint64{t[0], t[1], t[2], t[3]}
+
+	return mod.reduce(&r, t[4])
+}
+
+// toMont converts a, which must be below m, into Montgomery form.
+func (mod *modulus) toMont(a *[4]uint64) [4]uint64 {
+	return mod.mul(a, &mod.r2)
+}
+
+// fromMont converts a out of Montgomery form.
+func (mod *modulus) fromMont(a *[4]uint64) [4]uint64 {
+	one := [4]uint64{1, 0, 0, 0}
+
+	return mod.mul(a, &one)
+}
+
+// inverse returns a^-1 mod m in Montgomery form, computed as a^(m-2) by
+// Fermat's little theorem. The exponent is public, so the sequence of
+// operations does not depend on a. The inverse of zero is zero.
+func (mod *modulus) inverse(a *[4]uint64) [4]uint64 {
+	result := mod.one
+	for i := 3; i >= 0; i-- {
+		for bit := 63; bit >= 0; bit-- {
+			result = mod.mul(&result, &result)
+			if (mod.exp[i]>>uint(bit))&1 == 1 {
+				result = mod.mul(&result, a)
+			}
+		}
+	}
+
+	return result
+}
+
+// setBytes converts 32 big-endian bytes to Montgomery form, reducing the value
+// modulo m. It assumes 2^256 < 2m, which holds for both P and N.
+func (mod *modulus) setBytes(b *[32]byte) [4]uint64 {
+	l := limbsFromBytes(b)
+	l = mod.reduce(&l, 0)
+
+	return mod.toMont(&l)
+}
+
+// bytes converts a out of Montgomery form to 32 big-endian bytes.
+func (mod *modulus) bytes(a *[4]uint64) [32]byte {
+	l := mod.fromMont(a)
+
+	return limbsToBytes(&l)
+}
+
+// isZero returns 1 if a is zero and 0 otherwise, in constant time.
+func isZero(a *[4]uint64) uint64 {
+	x := a[0] | a[1] | a[2] | a[3]
+
+	return ((x | -x) >> 63) ^ 1
+}
@@ -0,0 +1,95 @@
+package secp256k1
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// TestFieldArithmetic will test the fixed-width field operations against
+// math/big.
+func TestFieldArithmetic(t *testing.T) {
+	secp256k1 := New()
+	P := secp256k1.P
+
+	for i := 0; i < 200; i++ {
+		a, _ := rand.Int(rand.Reader, P)
+		b, _ := rand.Int(rand.Reader, P)
+		fa, fb := fieldFromBig(a), fieldFromBig(b)
+
+		var r fieldVal
+		tests := []struct {
+			name     string
+			result   *big.Int
+			expected *big.Int
+		}{
+			{"add", r.add(&fa, &fb).big(), new(big.Int).Mod(new(big.Int).Add(a, b), P)},
+			{"sub", r.sub(&fa, &fb).big(), new(big.Int).Mod(new(big.Int).Sub(a, b), P)},
+			{"neg", r.neg(&fa).big(), new(big.Int).Mod(new(big.Int).Neg(a), P)},
+			{"mul", r.mul(&fa, &fb).big(), new(big.Int).Mod(new(big.Int).Mul(a, b), P)},
+			{"square", r.square(&fa).big(), new(big.Int).Mod(new(big.Int).Mul(a, a), P)},
+			{"inverse", r.inverse(&fa).big(), new(big.Int).ModInverse(a, P)},
+		}
+
+		for _, test := range tests {
+			if test.result.Cmp(test.expected) != 0 {
+				t.Fatalf("%v: a: %v, b: %v, expected: %v, received: %v",
+					test.name, a, b, test.expected, test.result)
+			}
+		}
+	}
+
+	// Values of P and above are reduced.
+	over := new(big.Int).Add(P, big.NewInt(5))
+	f := fieldFromBig(over)
+	if f.big().Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("expected 5, received: %v", f.big())
+	}
+
+	zero := fieldFromBig(P)
+	if zero.isZero() != 1 {
+		t.Fatalf("P should reduce to zero")
+	}
+}
+
+// TestScalarArithmetic will test the fixed-width scalar operations against
+// math/big.
+func TestScalarArithmetic(t *testing.T) {
+	secp256k1 := New()
+	N := secp256k1.N
+
+	for i := 0; i < 200; i++ {
+		a, _ := rand.Int(rand.Reader, N)
+		b, _ := rand.Int(rand.Reader, N)
+		sa, sb := scalarFromBig(a), scalarFromBig(b)
+
+		var r scalar
+		tests := []struct {
+			name     string
+			result   *big.Int
+			expected *big.Int
+		}{
+			{"add", r.add(&sa, &sb).big(), new(big.Int).Mod(new(big.Int).Add(a, b), N)},
+			{"sub", r.sub(&sa, &sb).big(), new(big.Int).Mod(new(big.Int).Sub(a, b), N)},
+			{"neg", r.neg(&sa).big(), new(big.Int).Mod(new(big.Int).Neg(a), N)},
+			{"mul", r.mul(&sa, &sb).big(), new(big.Int).Mod(new(big.Int).Mul(a, b), N)},
+			{"square", r.square(&sa).big(), new(big.Int).Mod(new(big.Int).Mul(a, a), N)},
+			{"inverse", r.inverse(&sa).big(), new(big.Int).ModInverse(a, N)},
+			{"ScalarInverse", secp256k1.ScalarInverse(a), new(big.Int).ModInverse(a, N)},
+		}
+
+		for _, test := range tests {
+			if test.result.Cmp(test.expected) != 0 {
+				t.Fatalf("%v: a: %v, b: %v, expected: %v, received: %v",
+					test.name, a, b, test.expected, test.result)
+			}
+		}
+	}
+
+	// Scalars longer than 32 bytes are reduced mod N.
+	long := new(big.Int).Add(new(big.Int).Lsh(N, 8), big.NewInt(7))
+	s := scalarFromBytes(long.Bytes())
+	if s.big().Cmp(big.NewInt(7)) != 0 {
+		t.Fatalf("expected 7, received: %v", s.big())
+	}
+}
@@ -0,0 +1,174 @@
+package secp256k1
+
+import (
+	"math/bits"
+)
+
+// pippengerThreshold is the number of points from which MultiScalarMult
+// switches from Strauss to Pippenger.
+const pippengerThreshold = 128
+
+// multiScalarMult sets pt to the sum of ks[i]*points[i], choosing the
+// algorithm by the number of points. It is not constant time and must only
+// be used with public scalars.
+func (pt *jacobianPoint) multiScalarMult(points []jacobianPoint, ks []scalar) *jacobianPoint {
+	switch {
+	case len(points) == 0:
+		*pt = infinity()
+		return pt
+	case len(points) == 1:
+		return pt.scalarMultVartime(&points[0], &ks[0])
+	case len(points) == 2:
+		return pt.shamirMult(points, ks)
+	case len(points) < pippengerThreshold:
+		return pt.straussMult(points, ks)
+	default:
+		return pt.pippengerMult(points, ks)
+	}
+}
+
+// glvExpand splits every scalar with the endomorphism, returning twice as many
+// points each paired with a scalar of at most 128 bits. The signs of the
+// halves are moved onto the points.
+func glvExpand(points []jacobianPoint, ks []scalar) ([]jacobianPoint, [][4]uint64) {
+	expanded := make([]jacobianPoint, 0, 2*len(points))
+	halves := make([][4]uint64, 0, 2*len(points))
+
+	for i := range points {
+		k1, k2, neg1, neg2 := splitScalar(&ks[i])
+
+		var p1, p2 jacobianPoint
+		p1.condNegate(&points[i], neg1)
+		p2.endomorphism(&points[i])
+		p2.condNegate(&p2, neg2)
+
+		expanded = append(expanded, p1, p2)
+		halves = append(halves, k1, k2)
+	}
+
+	return expanded, halves
+}
+
+// bitsAt returns width bits of k starting at bit pos. width must be less than
+// 64.
+func bitsAt(k *[4]uint64, pos, width uint) uint64 {
+	limb, shift := pos/64, pos%64
+	if limb >= 4 {
+		return 0
+	}
+
+	v := k[limb] >> shift
+	if shift+width > 64 && limb < 3 {
+		v |= k[limb+1] << (64 - shift)
+	}
+
+	return v & (1<<width - 1)
+}
+
+// shamirMult sets pt = ks[0]*points[0] + ks[1]*points[1] using Shamir's
+// trick. After the endomorphism split there are four 128-bit scalars, and a
+// table of every sum of a subset of the four points means each bit position
+// needs one doubling and at most one addition.
+func (pt *jacobianPoint) shamirMult(points []jacobianPoint, ks []scalar) *jacobianPoint {
+	expanded, halves := glvExpand(points[:2], ks[:2])
+
+	// table[mask] is the sum of the points whose bit is set in mask.
+	var table [16]jacobianPoint
+	table[0] = infinity()
+	for mask := 1; mask < 16; mask++ {
+		low := bits.TrailingZeros(uint(mask))
+		table[mask].addVartime(&table[mask&(mask-1)], &expanded[low])
+	}
+
+	acc := infinity()
+	for bit := 127; bit >= 0; bit-- {
+		acc.double(&acc)
+
+		var mask uint64
+		for j := range halves {
+			mask |= bitsAt(&halves[j], uint(bit), 1) << uint(j)
+		}
+		if mask != 0 {
+			acc.addVartime(&acc, &table[mask])
+		}
+	}
+
+	*pt = acc
+	return pt
+}
+
+// straussMult sets pt to the sum of ks[i]*points[i] using Strauss' method.
+// Each scalar is split with the endomorphism and recoded in wNAF, and the
+// doublings are shared between all of them.
+func (pt *jacobianPoint) straussMult(points []jacobianPoint, ks []scalar) *jacobianPoint {
+	expanded, halves := glvExpand(points, ks)
+
+	tables := make([][]jacobianPoint, len(expanded))
+	digits := make([][]int8, len(expanded))
+	length := 0
+	for i := range expanded {
+		tables[i] = oddMultiples(&expanded[i])
+		digits[i] = wnaf(halves[i], wnafWindow)
+		if len(digits[i]) > length {
+			length = len(digits[i])
+		}
+	}
+
+	acc := infinity()
+	for i := length - 1; i >= 0; i-- {
+		acc.double(&acc)
+		for j := range digits {
+			if i < len(digits[j]) {
+				acc.addDigit(tables[j], digits[j][i])
+			}
+		}
+	}
+
+	*pt = acc
+	return pt
+}
+
+// pippengerMult sets pt to the sum of ks[i]*points[i] using Pippenger's
+// bucket method. For every window of the scalars each point is added to the
+// bucket for its digit, and the buckets are summed with a running sum, so the
+// cost per point does not depend on the window size.
+func (pt *jacobianPoint) pippengerMult(points []jacobianPoint, ks []scalar) *jacobianPoint {
+	expanded, halves := glvExpand(points, ks)
+
+	// A window of about log2(n) bits balances adding every point once per
+	// window against summing the buckets.
+	width := uint(bits.Len(uint(len(expanded)))) - 2
+	if width < 2 {
+		width = 2
+	}
+
+	buckets := make([]jacobianPoint, 1<<width-1)
+	windows := (128 + width - 1) / width
+
+	acc := infinity()
+	for w := int(windows) - 1; w >= 0; w-- {
+		for i := uint(0); i < width; i++ {
+			acc.double(&acc)
+		}
+
+		for i := range buckets {
+			buckets[i] = infinity()
+		}
+		for i := range expanded {
+			if d := bitsAt(&halves[i], uint(w)*width, width); d != 0 {
+				buckets[d-1].addVartime(&buckets[d-1], &expanded[i])
+			}
+		}
+
+		// sum = 1*buckets[0] + 2*buckets[1] + ...
+		running, sum := infinity(), infinity()
+		for i := len(buckets) - 1; i >= 0; i-- {
+			running.addVartime(&running, &buckets[i])
+			sum.addVartime(&sum, &running)
+		}
+		acc.addVartime(&acc, &sum)
+	}
+
+	*pt = acc
+	return pt
+}
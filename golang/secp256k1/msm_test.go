@@ -0,0 +1,136 @@
+package secp256k1
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// msmInput returns n random points and scalars along with the sum of the
+// separate scalar multiplications. Some of the scalars are zero and some of
+// the points are repeated or negated so that partial sums cancel.
+func msmInput(t testing.TB, s *Secp256k1, n int) ([]jacobianPoint, []scalar, jacobianPoint) {
+	points := make([]jacobianPoint, n)
+	scalars := make([]scalar, n)
+	expected := infinity()
+
+	for i := 0; i < n; i++ {
+		p, err := rand.Int(rand.Reader, s.N)
+		if err != nil {
+			t.Fatalf("failed to generate a point: %v", err)
+		}
+		k, err := rand.Int(rand.Reader, s.N)
+		if err != nil {
+			t.Fatalf("failed to generate a scalar: %v", err)
+		}
+
+		base := scalarFromBig(p)
+		points[i].scalarBaseMult(&base)
+		scalars[i] = scalarFromBig(k)
+
+		switch {
+		case i%7 == 3:
+			scalars[i] = scalar{}
+		case i%5 == 4:
+			points[i] = points[i-1]
+		case i%5 == 2:
+			points[i].condNegate(&points[i-1], 1)
+			scalars[i] = scalars[i-1]
+		}
+
+		var term jacobianPoint
+		term.scalarMult(&points[i], &scalars[i])
+		expected.add(&expected, &term)
+	}
+
+	return points, scalars, expected
+}
+
+// equalPoints reports whether a and b are the same point.
+func equalPoints(a, b *jacobianPoint) bool {
+	ax, ay := a.affine()
+	bx, by := b.affine()
+
+	return ax.equal(&bx) == 1 && ay.equal(&by) == 1 && a.z.isZero() == b.z.isZero()
+}
+
+// TestMultiScalarMultAlgorithms will test that Shamir's trick, Strauss and
+// Pippenger all agree with adding separate scalar multiplications.
+func TestMultiScalarMultAlgorithms(t *testing.T) {
+	secp256k1 := New()
+
+	for _, n := range []int{1, 2, 3, 10, 40, pippengerThreshold + 3} {
+		points, scalars, expected := msmInput(t, secp256k1, n)
+
+		var received jacobianPoint
+		if n == 2 {
+			if !equalPoints(&expected, received.shamirMult(points, scalars)) {
+				t.Fatalf("n: %v, shamir does not match", n)
+			}
+		}
+		if !equalPoints(&expected, received.straussMult(points, scalars)) {
+			t.Fatalf("n: %v, strauss does not match", n)
+		}
+		if !equalPoints(&expected, received.pippengerMult(points, scalars)) {
+			t.Fatalf("n: %v, pippenger does not match", n)
+		}
+		if !equalPoints(&expected, received.multiScalarMult(points, scalars)) {
+			t.Fatalf("n: %v, multiScalarMult does not match", n)
+		}
+	}
+
+	// No points sum to the point at infinity.
+	var received jacobianPoint
+	if received.multiScalarMult(nil, nil).z.isZero() != 1 {
+		t.Fatalf("expected the point at infinity for no points")
+	}
+}
+
+// TestMultiScalarMultCancel will test that k*P + k*(-P) is the point at
+// infinity with Shamir's trick.
+func TestMultiScalarMultCancel(t *testing.T) {
+	secp256k1 := New()
+
+	k, _ := rand.Int(rand.Reader, secp256k1.N)
+	negGy := new(big.Int).Sub(secp256k1.P, secp256k1.Gy)
+
+	xs := []*big.Int{secp256k1.Gx, secp256k1.Gx}
+	ys := []*big.Int{secp256k1.Gy, negGy}
+	ks := [][]byte{k.Bytes(), k.Bytes()}
+
+	if _, _, z := secp256k1.MultiScalarMult(xs, ys, ks); z.Sign() != 0 {
+		t.Fatalf("expected the point at infinity, z: %v", z)
+	}
+}
+
+// BenchmarkMultiScalarMult will benchmark Strauss and Pippenger for different
+// numbers of points, which is used to choose pippengerThreshold.
+func BenchmarkMultiScalarMult(b *testing.B) {
+	secp256k1 := New()
+
+	for _, n := range []int{2, 32, 64, 128, 512} {
+		points, scalars, _ := msmInput(b, secp256k1, n)
+
+		b.Run(fmt.Sprintf("strauss/%v", n), func(b *testing.B) {
+			var pt jacobianPoint
+			for i := 0; i < b.N; i++ {
+				pt.straussMult(points, scalars)
+			}
+		})
+		b.Run(fmt.Sprintf("pippenger/%v", n), func(b *testing.B) {
+			var pt jacobianPoint
+			for i := 0; i < b.N; i++ {
+				pt.pippengerMult(points, scalars)
+			}
+		})
+		if n == 2 {
+			b.Run("shamir/2", func(b *testing.B) {
+				var pt jacobianPoint
+				for i := 0; i < b.N; i++ {
+					pt.shamirMult(points, scalars)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package secp256k1
+
+import (
+	"math/big"
+)
+
+// scalarN holds the Montgomery constants for arithmetic modulo N.
+var scalarN = newModulus(mustConvHex(n))
+
+// scalar is an integer modulo the group order N, stored as fixed 64-bit limbs
+// in Montgomery form. Every operation runs in constant time.
+type scalar struct {
+	l [4]uint64
+}
+
+// scalarFromBytes will create a scalar from a big-endian byte slice, reducing
+// it mod N. Slices of up to 32 bytes are handled in constant time.
+func scalarFromBytes(k []byte) scalar {
+	if len(k) > 32 {
+		k = new(big.Int).Mod(new(big.Int).SetBytes(k), scalarN.mBig()).Bytes()
+	}
+
+	var b [32]byte
+	copy(b[32-len(k):], k)
+
+	return scalar{scalarN.setBytes(&b)}
+}
+
+// scalarFromBig will create a scalar from a big Int, reducing it mod N.
+func scalarFromBig(x *big.Int) scalar {
+	if x.Sign() < 0 {
+		x = new(big.Int).Mod(x, scalarN.mBig())
+	}
+
+	return scalarFromBytes(x.Bytes())
+}
+
+// big converts the scalar to a big Int.
+func (s *scalar) big() *big.Int {
+	b := scalarN.bytes(&s.l)
+
+	return new(big.Int).SetBytes(b[:])
+}
+
+// limbs returns the scalar as little-endian limbs out of Montgomery form.
+func (s *scalar) limbs() [4]uint64 {
+	return scalarN.fromMont(&s.l)
+}
+
+// add sets s = a + b and returns s.
+func (s *scalar) add(a, b *scalar) *scalar {
+	s.l = scalarN.add(&a.l, &b.l)
+	return s
+}
+
+// sub sets s = a - b and returns s.
+func (s *scalar) sub(a, b *scalar) *scalar {
+	s.l = scalarN.sub(&a.l, &b.l)
+	return s
+}
+
+// neg sets s = -a and returns s.
+func (s *scalar) neg(a *scalar) *scalar {
+	var zero [4]uint64
+	s.l = scalarN.sub(&zero, &a.l)
+	return s
+}
+
+// mul sets s = a * b and returns s.
+func (s *scalar) mul(a, b *scalar) *scalar {
+	s.l = scalarN.mul(&a.l, &b.l)
+	return s
+}
+
+// square sets s = a^2 and returns s.
+func (s *scalar) square(a *scalar) *scalar {
+	s.l = scalarN.mul(&a.l, &a.l)
+	return s
+}
+
+// inverse sets s = a^-1 and returns s. The inverse of zero is zero.
+func (s *scalar) inverse(a *scalar) *scalar {
+	s.l = scalarN.inverse(&a.l)
+	return s
+}
+
+// isZero returns 1 if s is zero and 0 otherwise.
+func (s *scalar) isZero() uint64 {
+	return isZero(&s.l)
+}
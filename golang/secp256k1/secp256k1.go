@@ -1,6 +1,8 @@
 package secp256k1
 
 import (
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
 	"github.com/ccdle12/bitcoin-review/golang/utils"
 	"math/big"
 )
@@ -16,6 +18,9 @@ const (
 	n  = "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141" // Number of points in the field of secp256k1.
 )
 
+// Secp256k1 implements the curve.Curve interface.
+var _ curve.Curve = (*Secp256k1)(nil)
+
 // Secp256k1 is the implementation of this s.
 type Secp256k1 struct {
 	P  *big.Int
@@ -24,10 +29,12 @@ type Secp256k1 struct {
 	Gx *big.Int
 	Gy *big.Int
 	N  *big.Int
+
+	// blinded is set by NewBlinded to blind secret scalar multiplications.
+	blinded bool
 }
 
 // New is the constructor for the s Secp256k.
-// TODO: make this implement a Curve interface.
 func New() *Secp256k1 {
 	P, _ := utils.ConvHexStrToBigInt(p)
 	A, _ := utils.ConvHexStrToBigInt(a)
@@ -36,124 +43,67 @@ func New() *Secp256k1 {
 	Gy, _ := utils.ConvHexStrToBigInt(gy)
 	N, _ := utils.ConvHexStrToBigInt(n)
 
-	return &Secp256k1{P, A, B, Gx, Gy, N}
+	return &Secp256k1{P: P, A: A, B: B, Gx: Gx, Gy: Gy, N: N}
 }
 
-// TODO: EXPLAIN
-func (s *Secp256k1) JacobianAdd(x1, y1, z1, x2, y2, z2 *big.Int) (*big.Int, *big.Int, *big.Int) {
-	// See http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-add-2007-bl
-	z1z1 := new(big.Int).Mul(z1, z1)
-	z1z1.Mod(z1z1, s.P)
-	z2z2 := new(big.Int).Mul(z2, z2)
-	z2z2.Mod(z2z2, s.P)
-
-	u1 := new(big.Int).Mul(x1, z2z2)
-	u1.Mod(u1, s.P)
-
-	u2 := new(big.Int).Mul(x2, z1z1)
-	u2.Mod(u2, s.P)
-
-	h := new(big.Int).Sub(u2, u1)
-	// Check if h is less than 0.
-	if h.Sign() == -1 {
-		h.Add(h, s.P)
-	}
-	i := new(big.Int).Lsh(h, 1)
-	i.Mul(i, i)
-	j := new(big.Int).Mul(h, i)
-
-	s1 := new(big.Int).Mul(y1, z2)
-	s1.Mul(s1, z2z2)
-	s1.Mod(s1, s.P)
-	s2 := new(big.Int).Mul(y2, z1)
-	s2.Mul(s2, z1z1)
-	s2.Mod(s2, s.P)
-	r := new(big.Int).Sub(s2, s1)
-	// Check if r is less than 0.
-	if r.Sign() == -1 {
-		r.Add(r, s.P)
+// Params returns the domain parameters of secp256k1.
+func (s *Secp256k1) Params() *curve.Params {
+	return &curve.Params{
+		Name: "secp256k1",
+		P:    s.P,
+		A:    s.A,
+		B:    s.B,
+		Gx:   s.Gx,
+		Gy:   s.Gy,
+		N:    s.N,
 	}
-	r.Lsh(r, 1)
-	v := new(big.Int).Mul(u1, i)
-
-	x3 := new(big.Int).Set(r)
-	x3.Mul(x3, x3)
-	x3.Sub(x3, j)
-	x3.Sub(x3, v)
-	x3.Sub(x3, v)
-	x3.Mod(x3, s.P)
-
-	y3 := new(big.Int).Set(r)
-	v.Sub(v, x3)
-	y3.Mul(y3, v)
-	s1.Mul(s1, j)
-	s1.Lsh(s1, 1)
-	y3.Sub(y3, s1)
-	y3.Mod(y3, s.P)
-
-	z3 := new(big.Int).Add(z1, z2)
-	z3.Mul(z3, z3)
-	z3.Sub(z3, z1z1)
-	if z3.Sign() == -1 {
-		z3.Add(z3, s.P)
-	}
-	z3.Sub(z3, z2z2)
-	if z3.Sign() == -1 {
-		z3.Add(z3, s.P)
-	}
-	z3.Mul(z3, h)
-	z3.Mod(z3, s.P)
+}
 
-	return x3, y3, z3
+// JacobianAdd adds the points (x1, y1, z1) and (x2, y2, z2) in Jacobian
+// co-ordinates. A z of 0 represents the point at infinity. The arithmetic is
+// done on fixed-width field elements in constant time.
+func (s *Secp256k1) JacobianAdd(x1, y1, z1, x2, y2, z2 *big.Int) (*big.Int, *big.Int, *big.Int) {
+	a := jacobianFromBig(x1, y1, z1)
+	b := jacobianFromBig(x2, y2, z2)
 
+	return a.add(&a, &b).big()
 }
 
-// TODO: EXPLAIN
+// JacobianDouble doubles the point (x, y, z) in Jacobian co-ordinates, in
+// constant time.
 func (s *Secp256k1) JacobianDouble(x, y, z *big.Int) (*big.Int, *big.Int, *big.Int) {
-	// See http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#doubling-dbl-2009-l
-	a := new(big.Int).Mul(x, x) // X1^2
-	b := new(big.Int).Mul(y, y) // Y1^2
-	c := new(big.Int).Mul(b, b) // B^2
-
-	d := new(big.Int).Add(x, b) //X1 + B
-	d.Mul(d, d)                 // (X1 +B)^2
-	d.Sub(d, a)                 //  (X1 + B)^2 - A
-	d.Sub(d, c)                 //  (X1 + B)^2 - C
-	d.Mul(d, big.NewInt(2))     // 2 * ((X1 + B)^2 - A - C)
-
-	e := new(big.Int).Mul(big.NewInt(3), a) // 3 * A
-	f := new(big.Int).Mul(e, e)             // E^2
-
-	x3 := new(big.Int).Mul(big.NewInt(2), d) // 2 * D
-	x3.Sub(f, x3)                            // F - 2 * D
-	x3.Mod(x3, s.P)
+	a := jacobianFromBig(x, y, z)
 
-	y3 := new(big.Int).Sub(d, x3)                  // D - X3
-	y3.Mul(e, y3)                                  // E * (D-X3)
-	y3.Sub(y3, new(big.Int).Mul(big.NewInt(8), c)) // E * (D-X3) - 8 * C
-	y3.Mod(y3, s.P)
-
-	z3 := new(big.Int).Mul(y, z) // Y1 * Z1
-	z3.Mul(big.NewInt(2), z3)
-	z3.Mod(z3, s.P)
-
-	return x3, y3, z3
+	return a.double(&a).big()
 }
 
-// TODO: EXPLAIN
+// AffineFromJacobian converts the point (x, y, z) in Jacobian co-ordinates to
+// affine co-ordinates. The point at infinity is returned as (0, 0).
 func (s *Secp256k1) AffineFromJacobian(x, y, z *big.Int) (*big.Int, *big.Int) {
-	zinv := new(big.Int).ModInverse(z, s.P)
-	zinvsq := new(big.Int).Mul(zinv, zinv)
+	a := jacobianFromBig(x, y, z)
+	ax, ay := a.affine()
 
-	xOut := new(big.Int).Mul(x, zinvsq)
-	xOut.Mod(xOut, s.P)
+	return ax.big(), ay.big()
+}
 
-	zinvsq.Mul(zinvsq, zinv)
+// BatchAffineFromJacobian converts many points in Jacobian co-ordinates to
+// affine co-ordinates with a single field inversion. The point at infinity is
+// returned as (0, 0).
+func (s *Secp256k1) BatchAffineFromJacobian(xs, ys, zs []*big.Int) ([]*big.Int, []*big.Int) {
+	points := make([]jacobianPoint, len(xs))
+	for i := range points {
+		points[i] = jacobianFromBig(xs[i], ys[i], zs[i])
+	}
+
+	ax, ay := batchAffine(points)
 
-	yOut := new(big.Int).Mul(y, zinvsq)
-	yOut.Mod(yOut, s.P)
+	rx := make([]*big.Int, len(points))
+	ry := make([]*big.Int, len(points))
+	for i := range points {
+		rx[i], ry[i] = ax[i].big(), ay[i].big()
+	}
 
-	return xOut, yOut
+	return rx, ry
 }
 
 // IsOnCurve is a function to check whether the x,y co-ordinates satisfy the
@@ -174,76 +124,165 @@ func (s *Secp256k1) IsOnCurve(x, y *big.Int) bool {
 	return x2.Cmp(y2) == 0
 }
 
-// ScalarMult is the open function for scalar multiplication on a curve.
-func (s *Secp256k1) ScalarMult(k []byte) (*big.Int, *big.Int, *big.Int) {
-	// Assign Bx, By, and Bz as the base.
-	Bx := s.Gx
-	By := s.Gy
-	Bz := new(big.Int).SetInt64(1)
-
-	// x, y, z will be used for point doubling.
-	x := Bx
-	y := By
-	z := Bz
-
-	// Loop over the bytes of the secret k.
-	// Uses the double and add algorithm.
-	for _, byte := range k {
-		for bitNum := 0; bitNum < 8; bitNum++ {
-			x, y, z = s.jacobianDouble(x, y, z)
-
-			if byte&0x80 == 0x80 {
-				x, y, z = s.JacobianAdd(Bx, By, Bz, x, y, z)
-			}
-			// TODO: (ccdle12) need to look intowhy we need to shift the byte
-			// by 1.
-			// byte <<= 1
-		}
+// JacobianFromAffine converts the point (x, y) in affine co-ordinates to
+// Jacobian co-ordinates by setting z to 1.
+func (s *Secp256k1) JacobianFromAffine(x, y *big.Int) (*big.Int, *big.Int, *big.Int) {
+	return new(big.Int).Set(x), new(big.Int).Set(y), big.NewInt(1)
+}
+
+// DecompressY will calculate the y co-ordinate for x by taking the modular
+// square root of x^3 + ax + b. Since P = 3 mod 4 the root is
+// (x^3 + ax + b)^((P+1)/4). odd selects which of the two roots is returned.
+func (s *Secp256k1) DecompressY(x *big.Int, odd bool) (*big.Int, error) {
+	if x.Sign() < 0 || x.Cmp(s.P) >= 0 {
+		return nil, errors.New("x co-ordinate is not in the field")
+	}
+
+	// alpha = x^3 + ax + b
+	alpha := new(big.Int).Mul(x, x)
+	alpha.Mul(alpha, x)
+	ax := new(big.Int).Mul(s.A, x)
+	alpha.Add(alpha, ax)
+	alpha.Add(alpha, s.B)
+	alpha.Mod(alpha, s.P)
+
+	// beta = alpha^((P+1)/4)
+	exp := new(big.Int).Add(s.P, big.NewInt(1))
+	exp.Rsh(exp, 2)
+	beta := new(big.Int).Exp(alpha, exp, s.P)
+
+	// Check that beta is actually a square root of alpha.
+	check := new(big.Int).Mul(beta, beta)
+	check.Mod(check, s.P)
+	if check.Cmp(alpha) != 0 {
+		return nil, errors.New("x co-ordinate is not on the curve")
+	}
+
+	if (beta.Bit(0) == 1) != odd {
+		beta.Sub(s.P, beta)
 	}
 
-	return x, y, z
+	return beta, nil
 }
 
-// GenericScalarMult is the open function to use scalar multiplication without assuming the use of Gx and Gy.
-func (s *Secp256k1) GenericScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int, *big.Int) {
-	Bz := new(big.Int).SetInt64(1)
-	// x, y, z := new(big.Int), new(big.Int), new(big.Int)
-
-	x := Bx
-	y := By
-	z := Bz
-
-	for _, byte := range k {
-		for bitNum := 0; bitNum < 8; bitNum++ {
-			x, y, z = s.jacobianDouble(x, y, z)
-			if byte&0x80 == 0x80 {
-				x, y, z = s.JacobianAdd(Bx, By, Bz, x, y, z)
-			}
-			byte <<= 1
-		}
+// ScalarBaseMult is the open function for scalar multiplication of the
+// generator point. It uses a table of precomputed multiples of G, so it only
+// needs additions, and runs in constant time. It is blinded when s was
+// created with NewBlinded.
+func (s *Secp256k1) ScalarBaseMult(k []byte) (*big.Int, *big.Int, *big.Int) {
+	scalar := scalarFromBytes(k)
+
+	var result jacobianPoint
+	if s.blinded {
+		return result.scalarBaseMultBlinded(&scalar).big()
+	}
+	return result.scalarBaseMult(&scalar).big()
+}
+
+// ScalarMult is the open function to use scalar multiplication without
+// assuming the use of Gx and Gy. k is reduced mod N and split with the GLV
+// endomorphism. The multiplication runs in constant time, so it is safe to
+// use with secret scalars. It is blinded when s was created with NewBlinded.
+func (s *Secp256k1) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int, *big.Int) {
+	base := jacobianFromBig(Bx, By, big.NewInt(1))
+	scalar := scalarFromBytes(k)
+
+	var result jacobianPoint
+	if s.blinded {
+		return result.scalarMultBlinded(&base, &scalar).big()
+	}
+	return result.scalarMult(&base, &scalar).big()
+}
+
+// ScalarMultVartime returns the same result as ScalarMult, using the GLV
+// endomorphism with wNAF recoding. It is about twice as fast as ScalarMult but
+// is not constant time, so it must only be used with public scalars such as
+// in signature verification.
+func (s *Secp256k1) ScalarMultVartime(Bx, By *big.Int, k []byte) (*big.Int, *big.Int, *big.Int) {
+	base := jacobianFromBig(Bx, By, big.NewInt(1))
+	scalar := scalarFromBytes(k)
+
+	var result jacobianPoint
+	return result.scalarMultVartime(&base, &scalar).big()
+}
+
+// MultiScalarMult is the open function to compute the sum of ks[i]*(xs[i],
+// ys[i]). Two points use Shamir's trick, small batches use Strauss' method
+// and large batches use Pippenger's bucket method. It is not constant time and
+// must only be used with public scalars.
+func (s *Secp256k1) MultiScalarMult(xs, ys []*big.Int, ks [][]byte) (*big.Int, *big.Int, *big.Int) {
+	points := make([]jacobianPoint, len(ks))
+	scalars := make([]scalar, len(ks))
+	for i, k := range ks {
+		points[i] = jacobianFromBig(xs[i], ys[i], big.NewInt(1))
+		scalars[i] = scalarFromBytes(k)
 	}
 
-	return x, y, z
+	var result jacobianPoint
+	return result.multiScalarMult(points, scalars).big()
 }
 
-// SimpleAdd will be an alternative to the jacobianAdd for adding different x,y co-orindates.
+// ScalarInverse returns k^-1 mod N, computed in constant time so it is safe
+// to use with secret nonces.
+func (s *Secp256k1) ScalarInverse(k *big.Int) *big.Int {
+	a := scalarFromBig(k)
+
+	var inv scalar
+	return inv.inverse(&a).big()
+}
+
+// GenericScalarMult multiplies an arbitrary point (Bx, By) by k.
+//
+// Deprecated: use ScalarMult.
+func (s *Secp256k1) GenericScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int, *big.Int) {
+	return s.ScalarMult(Bx, By, k)
+}
+
+// SimpleAdd will add the affine points (x1, y1) and (x2, y2) without
+// converting to Jacobian co-ordinates. The point at infinity is (0, 0), which
+// is returned when adding a point to its negation.
 func (s *Secp256k1) SimpleAdd(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
-	// DO I need to mod everything?
+	if x1.Sign() == 0 && y1.Sign() == 0 {
+		return new(big.Int).Set(x2), new(big.Int).Set(y2)
+	}
+	if x2.Sign() == 0 && y2.Sign() == 0 {
+		return new(big.Int).Set(x1), new(big.Int).Set(y1)
+	}
+
+	var slope *big.Int
+	if x1.Cmp(x2) == 0 {
+		// P + -P is the point at infinity.
+		sum := new(big.Int).Add(y1, y2)
+		if sum.Mod(sum, s.P).Sign() == 0 {
+			return new(big.Int), new(big.Int)
+		}
 
-	// Slope is the delta between x1,y1 and x2,y2
-	s1 := new(big.Int).Sub(y1, y2)
-	s2 := new(big.Int).Sub(x1, x2)
-	slope := new(big.Int).Div(s1, s2)
+		// P + P is a doubling, the slope is the tangent 3x^2 / 2y.
+		slope = new(big.Int).Mul(x1, x1)
+		slope.Mul(slope, big.NewInt(3))
+		denom := new(big.Int).Lsh(y1, 1)
+		slope.Mul(slope, denom.ModInverse(denom, s.P))
+	} else {
+		// The slope is (y2 - y1) / (x2 - x1), dividing by the modular
+		// inverse.
+		slope = new(big.Int).Sub(y2, y1)
+		denom := new(big.Int).Sub(x2, x1)
+		denom.Mod(denom, s.P)
+		slope.Mul(slope, denom.ModInverse(denom, s.P))
+	}
+	slope.Mod(slope, s.P)
 
-	// Calculate the new x point = x3 = slope^2 - x1 - x2
-	x3 := new(big.Int).Exp(slope, big.NewInt(2), nil)
+	// x3 = slope^2 - x1 - x2
+	x3 := new(big.Int).Mul(slope, slope)
 	x3.Sub(x3, x1)
 	x3.Sub(x3, x2)
+	x3.Mod(x3, s.P)
 
-	// Calculate the new y point = y3 = slope * (x1 - x3) - y1
+	// y3 = slope * (x1 - x3) - y1
 	y3 := new(big.Int).Sub(x1, x3)
 	y3.Mul(slope, y3)
 	y3.Sub(y3, y1)
+	y3.Mod(y3, s.P)
 
 	return x3, y3
 }
@@ -111,7 +111,7 @@ func TestImplemenationScalarMultiply(t *testing.T) {
 	// Uses the double and add algorithm.
 	for _, byte := range k {
 		for bitNum := 0; bitNum < 8; bitNum++ {
-			x, y, z = secp256k1.jacobianDouble(x, y, z)
+			x, y, z = secp256k1.JacobianDouble(x, y, z)
 
 			if byte&0x80 == 0x80 {
 				x, y, z = secp256k1.JacobianAdd(Bx, By, Bz, x, y, z)
@@ -133,3 +133,146 @@ func TestImplemenationScalarMultiply(t *testing.T) {
 		t.Fatalf("xOut: %v and yOut: %v are not valid for the curve", xOut, yOut)
 	}
 }
+
+// TestScalarBaseMult will test that multiplying the generator point by small
+// scalars returns the known multiples of G.
+func TestScalarBaseMult(t *testing.T) {
+	secp256k1 := New()
+
+	tests := []struct {
+		k string
+		x string
+		y string
+	}{
+		{
+			"01",
+			"79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798",
+			"483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8",
+		},
+		{
+			"02",
+			"C6047F9441ED7D6D3045406E95C07CD85C778E4B8CEF3CA7ABAC09B95C709EE5",
+			"1AE168FEA63DC339A3C58419466CEAEEF7F632653266D0E1236431A950CFE52A",
+		},
+		{
+			"03",
+			"F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			"388F7B0F632DE8140FE337E62A37F3566500A99934C2231B6CB9FD7584B8E672",
+		},
+	}
+
+	for _, test := range tests {
+		k, _ := hex.DecodeString(test.k)
+		x, y := secp256k1.AffineFromJacobian(secp256k1.ScalarBaseMult(k))
+
+		if fmt.Sprintf("%064X", x) != test.x || fmt.Sprintf("%064X", y) != test.y {
+			t.Fatalf("k: %v, expected: (%v, %v), received: (%064X, %064X)",
+				test.k, test.x, test.y, x, y)
+		}
+	}
+
+	// Multiplying by N should return the point at infinity.
+	_, _, z := secp256k1.ScalarBaseMult(secp256k1.N.Bytes())
+	if z.Sign() != 0 {
+		t.Fatalf("N*G should be the point at infinity, z: %v", z)
+	}
+}
+
+// TestDecompressY will test that we can recover both y co-ordinates of the
+// generator point from its x co-ordinate.
+func TestDecompressY(t *testing.T) {
+	secp256k1 := New()
+
+	// Gy is even.
+	y, err := secp256k1.DecompressY(secp256k1.Gx, false)
+	if err != nil {
+		t.Fatalf("failed to decompress y: %v", err)
+	}
+	if y.Cmp(secp256k1.Gy) != 0 {
+		t.Fatalf("expected y: %v, received: %v", secp256k1.Gy, y)
+	}
+
+	y, err = secp256k1.DecompressY(secp256k1.Gx, true)
+	if err != nil {
+		t.Fatalf("failed to decompress y: %v", err)
+	}
+	expected := new(big.Int).Sub(secp256k1.P, secp256k1.Gy)
+	if y.Cmp(expected) != 0 {
+		t.Fatalf("expected y: %v, received: %v", expected, y)
+	}
+
+	// x = 5 has no point on the curve, since 5^3 + 7 is not a square.
+	if _, err := secp256k1.DecompressY(big.NewInt(5), false); err == nil {
+		t.Fatalf("should have failed to decompress an x not on the curve")
+	}
+}
+
+// TestMultiScalarMult will test that the multi scalar multiplication is equal
+// to adding the results of separate scalar multiplications.
+func TestMultiScalarMult(t *testing.T) {
+	secp256k1 := New()
+
+	var xs, ys []*big.Int
+	var ks [][]byte
+	ex, ey, ez := big.NewInt(1), big.NewInt(1), new(big.Int)
+
+	for i := 0; i < 5; i++ {
+		p, _ := rand.Int(rand.Reader, secp256k1.N)
+		k, _ := rand.Int(rand.Reader, secp256k1.N)
+
+		x, y := secp256k1.AffineFromJacobian(secp256k1.ScalarBaseMult(p.Bytes()))
+		xs = append(xs, x)
+		ys = append(ys, y)
+		ks = append(ks, k.Bytes())
+
+		jx, jy, jz := secp256k1.ScalarMult(x, y, k.Bytes())
+		ex, ey, ez = secp256k1.JacobianAdd(ex, ey, ez, jx, jy, jz)
+	}
+
+	x, y := secp256k1.AffineFromJacobian(secp256k1.MultiScalarMult(xs, ys, ks))
+	expectedX, expectedY := secp256k1.AffineFromJacobian(ex, ey, ez)
+
+	if x.Cmp(expectedX) != 0 || y.Cmp(expectedY) != 0 {
+		t.Fatalf("expected: (%v, %v), received: (%v, %v)", expectedX, expectedY, x, y)
+	}
+}
+
+// TestSimpleAdd will test that affine addition agrees with JacobianAdd for
+// distinct points, doubling, a point and its negation, and the point at
+// infinity.
+func TestSimpleAdd(t *testing.T) {
+	secp256k1 := New()
+
+	for i := 0; i < 10; i++ {
+		a, _ := rand.Int(rand.Reader, secp256k1.N)
+		b, _ := rand.Int(rand.Reader, secp256k1.N)
+		ax, ay := secp256k1.AffineFromJacobian(secp256k1.ScalarBaseMult(a.Bytes()))
+		bx, by := secp256k1.AffineFromJacobian(secp256k1.ScalarBaseMult(b.Bytes()))
+		negAy := new(big.Int).Sub(secp256k1.P, ay)
+		zero := new(big.Int)
+
+		cases := [][4]*big.Int{
+			{ax, ay, bx, by},
+			{ax, ay, ax, ay},
+			{ax, ay, ax, negAy},
+			{zero, zero, bx, by},
+			{ax, ay, zero, zero},
+		}
+		for j, c := range cases {
+			x1, y1, z1 := secp256k1.JacobianFromAffine(c[0], c[1])
+			x2, y2, z2 := secp256k1.JacobianFromAffine(c[2], c[3])
+			if c[0].Sign() == 0 {
+				z1.SetInt64(0)
+			}
+			if c[2].Sign() == 0 {
+				z2.SetInt64(0)
+			}
+			ex, ey := secp256k1.AffineFromJacobian(secp256k1.JacobianAdd(x1, y1, z1, x2, y2, z2))
+
+			x, y := secp256k1.SimpleAdd(c[0], c[1], c[2], c[3])
+			if x.Cmp(ex) != 0 || y.Cmp(ey) != 0 {
+				t.Fatalf("case %v, expected: (%v, %v), received: (%v, %v)", j, ex, ey, x, y)
+			}
+		}
+	}
+}
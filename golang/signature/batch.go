@@ -0,0 +1,122 @@
+package signature
+
+import (
+	"crypto/rand"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"math/big"
+)
+
+// SchnorrBatchItem is a single (public key, message, signature) triple to be
+// checked by VerifySchnorrBatch.
+type SchnorrBatchItem struct {
+	PubKey []byte
+	Msg    []byte
+	Sig    []byte
+}
+
+// VerifySchnorrBatch will check many BIP 340 Schnorr signatures at once. It
+// checks a random linear combination of the verification equations,
+//
+//	(a_1*s_1 + ... + a_n*s_n)*G = a_1*R_1 + ... + a_n*R_n + a_1*e_1*P_1 + ... + a_n*e_n*P_n
+//
+// with a single multi scalar multiplication. If the batch fails, each
+// signature is verified on its own and the index of the first invalid one is
+// returned, otherwise the index is -1.
+func VerifySchnorrBatch(c curve.Curve, items []SchnorrBatchItem) (bool, int) {
+	if verifySchnorrBatch(c, items) {
+		return true, -1
+	}
+
+	// Pinpoint the invalid signature.
+	for i, item := range items {
+		if !VerifySchnorr(c, item.PubKey, item.Msg, item.Sig) {
+			return false, i
+		}
+	}
+
+	// Every signature is valid on its own, which can only happen if the
+	// random coefficients were unlucky.
+	return true, -1
+}
+
+// verifySchnorrBatch reports whether the random linear combination of the
+// verification equations holds.
+func verifySchnorrBatch(c curve.Curve, items []SchnorrBatchItem) bool {
+	params := c.Params()
+
+	var xs, ys []*big.Int
+	var ks [][]byte
+
+	// sum is the sum of a_i*s_i, which is subtracted as a multiple of G.
+	sum := new(big.Int)
+
+	for i, item := range items {
+		if len(item.Sig) != SchnorrSigLen {
+			return false
+		}
+
+		publicKey, err := keys.PublicKeyFromXOnly(c, item.PubKey)
+		if err != nil {
+			return false
+		}
+
+		r := new(big.Int).SetBytes(item.Sig[:32])
+		if r.Cmp(params.P) >= 0 {
+			return false
+		}
+		s := new(big.Int).SetBytes(item.Sig[32:])
+		if s.Cmp(params.N) >= 0 {
+			return false
+		}
+
+		// R_i = lift_x(r_i)
+		ry, err := c.DecompressY(r, false)
+		if err != nil {
+			return false
+		}
+
+		// a_1 is 1 and every other a_i is random in [1, n).
+		a := big.NewInt(1)
+		if i > 0 {
+			a, err = randScalar(params.N)
+			if err != nil {
+				return false
+			}
+		}
+
+		e := schnorrChallenge(params.N, item.Sig[:32], item.PubKey, item.Msg)
+		ae := new(big.Int).Mul(a, e)
+		ae.Mod(ae, params.N)
+
+		as := new(big.Int).Mul(a, s)
+		sum.Add(sum, as)
+
+		xs = append(xs, r, publicKey.X)
+		ys = append(ys, ry, publicKey.Y)
+		ks = append(ks, a.Bytes(), ae.Bytes())
+	}
+
+	// Add -sum*G so that the combination sums to the point at infinity.
+	sum.Mod(sum, params.N)
+	sum.Sub(params.N, sum)
+	sum.Mod(sum, params.N)
+	xs = append(xs, params.Gx)
+	ys = append(ys, params.Gy)
+	ks = append(ks, sum.Bytes())
+
+	_, _, z := c.MultiScalarMult(xs, ys, ks)
+
+	return z.Sign() == 0
+}
+
+// randScalar returns a random integer in the range [1, n).
+func randScalar(n *big.Int) (*big.Int, error) {
+	max := new(big.Int).Sub(n, big.NewInt(1))
+	a, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Add(a, big.NewInt(1)), nil
+}
@@ -0,0 +1,96 @@
+package signature
+
+import (
+	"fmt"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"testing"
+)
+
+// generateBatch will create n valid Schnorr signatures from random keys.
+func generateBatch(t testing.TB, n int) []SchnorrBatchItem {
+	curve := secp256k1.New()
+
+	items := make([]SchnorrBatchItem, n)
+	for i := range items {
+		k, err := keys.New(curve)
+		if err != nil {
+			t.Fatalf("failed to generate keys: %v", err)
+		}
+
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig, err := SignSchnorr(curve, k.PrivateKey, msg, nil)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+
+		items[i] = SchnorrBatchItem{PubKey: k.PublicKey.XOnly(), Msg: msg, Sig: sig}
+	}
+
+	return items
+}
+
+// TestVerifySchnorrBatch will test that a batch of valid signatures passes.
+func TestVerifySchnorrBatch(t *testing.T) {
+	curve := secp256k1.New()
+	items := generateBatch(t, 8)
+
+	valid, index := VerifySchnorrBatch(curve, items)
+	if !valid || index != -1 {
+		t.Fatalf("expected a valid batch, received: %v, %v", valid, index)
+	}
+}
+
+// TestVerifySchnorrBatchInvalid will test that the batch fails and reports
+// the index of an invalid signature.
+func TestVerifySchnorrBatchInvalid(t *testing.T) {
+	curve := secp256k1.New()
+	items := generateBatch(t, 8)
+
+	// Swap the message of one of the signatures.
+	items[5].Msg = []byte("a different message")
+
+	valid, index := VerifySchnorrBatch(curve, items)
+	if valid || index != 5 {
+		t.Fatalf("expected an invalid batch at index 5, received: %v, %v", valid, index)
+	}
+
+	// Include a BIP 340 test vector that is invalid.
+	items = generateBatch(t, 3)
+	items[2] = SchnorrBatchItem{
+		PubKey: hexBytes(schnorrVectors[8].publicKey),
+		Msg:    hexBytes(schnorrVectors[8].msg),
+		Sig:    hexBytes(schnorrVectors[8].sig),
+	}
+
+	valid, index = VerifySchnorrBatch(curve, items)
+	if valid || index != 2 {
+		t.Fatalf("expected an invalid batch at index 2, received: %v, %v", valid, index)
+	}
+}
+
+// BenchmarkVerifySchnorrBatch will benchmark verifying 64 signatures as a
+// batch.
+func BenchmarkVerifySchnorrBatch(b *testing.B) {
+	curve := secp256k1.New()
+	items := generateBatch(b, 64)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifySchnorrBatch(curve, items)
+	}
+}
+
+// BenchmarkVerifySchnorrLoop will benchmark verifying 64 signatures one at a
+// time, for comparison with BenchmarkVerifySchnorrBatch.
+func BenchmarkVerifySchnorrLoop(b *testing.B) {
+	curve := secp256k1.New()
+	items := generateBatch(b, 64)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, item := range items {
+			VerifySchnorr(curve, item.PubKey, item.Msg, item.Sig)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package signature
+
+import (
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"math/big"
+)
+
+const (
+	// CompactSigLen is the length of a compact signature, a header byte
+	// followed by 32 bytes of R and 32 bytes of S.
+	CompactSigLen = 65
+
+	// compactHeaderBase is added to the recovery id to form the header byte.
+	compactHeaderBase = 27
+
+	// compactHeaderCompressed is added to the header byte when the recovered
+	// Public Key should be serialized as compressed.
+	compactHeaderCompressed = 4
+)
+
+// SignCompact will sign a 32-byte message hash with the Private Key pk and
+// return a 65-byte compact signature. The header byte is 27 + recovery id,
+// plus 4 if compressed is true.
+func SignCompact(c curve.Curve, pk *keys.PrivateKey, hash []byte, compressed bool) ([]byte, error) {
+	sig, recoveryID, err := signRecoverable(c, pk, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	header := compactHeaderBase + recoveryID
+	if compressed {
+		header += compactHeaderCompressed
+	}
+
+	return sig.compact(header), nil
+}
+
+// compact will serialize the Signature as <header><R><S> with R and S each
+// padded to 32 bytes.
+func (sig *Signature) compact(header byte) []byte {
+	result := make([]byte, CompactSigLen)
+	result[0] = header
+
+	rbin := sig.R.Bytes()
+	sbin := sig.S.Bytes()
+	copy(result[33-len(rbin):33], rbin)
+	copy(result[65-len(sbin):65], sbin)
+
+	return result
+}
+
+// ParseCompact will parse the R and S values of a 65-byte compact signature,
+// ignoring the header byte.
+func ParseCompact(sig []byte) (*Signature, error) {
+	if len(sig) != CompactSigLen {
+		return nil, errors.New("compact signature must be 65 bytes")
+	}
+
+	return &Signature{
+		R: new(big.Int).SetBytes(sig[1:33]),
+		S: new(big.Int).SetBytes(sig[33:65]),
+	}, nil
+}
+
+// RecoverCompact will recover the Public Key that produced the compact
+// signature sig over the 32-byte message hash. It also returns whether the
+// header byte marked the Public Key as compressed.
+func RecoverCompact(c curve.Curve, hash, sig []byte) (*keys.PublicKey, bool, error) {
+	if len(sig) != CompactSigLen {
+		return nil, false, errors.New("compact signature must be 65 bytes")
+	}
+
+	header := sig[0]
+	if header < compactHeaderBase || header >= compactHeaderBase+8 {
+		return nil, false, errors.New("invalid compact signature header byte")
+	}
+
+	header -= compactHeaderBase
+	compressed := header&compactHeaderCompressed != 0
+	recoveryID := header & 3
+
+	s, err := ParseCompact(sig)
+	if err != nil {
+		return nil, false, err
+	}
+
+	publicKey, err := RecoverPublicKey(c, hash, s, recoveryID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return publicKey, compressed, nil
+}
+
+// RecoverPublicKey will recover the Public Key from an ECDSA signature over
+// the 32-byte message hash, given the recovery id that was produced when
+// signing.
+func RecoverPublicKey(c curve.Curve, hash []byte, sig *Signature, recoveryID byte) (*keys.PublicKey, error) {
+	if len(hash) != 32 {
+		return nil, errors.New("message hash must be 32 bytes")
+	}
+	if recoveryID > 3 {
+		return nil, errors.New("recovery id must be between 0 and 3")
+	}
+
+	params := c.Params()
+
+	// R and S must both be in the range [1, N).
+	if sig.R.Sign() <= 0 || sig.R.Cmp(params.N) >= 0 {
+		return nil, errors.New("signature R is out of range")
+	}
+	if sig.S.Sign() <= 0 || sig.S.Cmp(params.N) >= 0 {
+		return nil, errors.New("signature S is out of range")
+	}
+
+	// The x co-ordinate of R is r, or r + N if bit 1 of the recovery id is
+	// set.
+	x := new(big.Int).Set(sig.R)
+	if recoveryID&2 != 0 {
+		x.Add(x, params.N)
+		if x.Cmp(params.P) >= 0 {
+			return nil, errors.New("recovered x co-ordinate is not in the field")
+		}
+	}
+
+	// Decompress R, bit 0 of the recovery id is the parity of y.
+	y, err := c.DecompressY(x, recoveryID&1 == 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Q = r^-1 * (s*R - z*G) = (-z/r)*G + (s/r)*R
+	rInv := new(big.Int).ModInverse(sig.R, params.N)
+	z := new(big.Int).SetBytes(hash)
+
+	u1 := new(big.Int).Neg(z)
+	u1.Mul(u1, rInv)
+	u1.Mod(u1, params.N)
+	u2 := new(big.Int).Mul(sig.S, rInv)
+	u2.Mod(u2, params.N)
+
+	x1, y1, z1 := c.ScalarBaseMult(u1.Bytes())
+	x2, y2, z2 := scalarMultPublic(c, x, y, u2.Bytes())
+	qx, qy, qz := c.JacobianAdd(x1, y1, z1, x2, y2, z2)
+	if qz.Sign() == 0 {
+		return nil, errors.New("recovered public key is the point at infinity")
+	}
+
+	px, py := c.AffineFromJacobian(qx, qy, qz)
+
+	return &keys.PublicKey{Point: curve.Point{Curve: c, X: px, Y: py}}, nil
+}
@@ -0,0 +1,98 @@
+package signature
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"testing"
+)
+
+// TestSignCompact will test that a compact signature of a known Private Key
+// has the expected header, R and S.
+func TestSignCompact(t *testing.T) {
+	curve := secp256k1.New()
+
+	privateKey, err := keys.PrivateKeyFromBytes(curve, []byte{0x01})
+	if err != nil {
+		t.Fatalf("failed to create private key: %v", err)
+	}
+
+	hash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+	sig, err := SignCompact(curve, privateKey, hash[:], true)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	if len(sig) != CompactSigLen {
+		t.Fatalf("expected length: %v, received: %v", CompactSigLen, len(sig))
+	}
+
+	if sig[0] < 31 || sig[0] > 34 {
+		t.Fatalf("expected a compressed header byte, received: %v", sig[0])
+	}
+
+	expected := "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8" +
+		"2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5"
+	if hex.EncodeToString(sig[1:]) != expected {
+		t.Fatalf("expected: %v, received: %x", expected, sig[1:])
+	}
+}
+
+// TestRecoverCompact will test that we recover the signer's Public Key and
+// compressed flag from compact signatures.
+func TestRecoverCompact(t *testing.T) {
+	curve := secp256k1.New()
+
+	for i := 0; i < 10; i++ {
+		k, err := keys.New(curve)
+		if err != nil {
+			t.Fatalf("failed to generate keys: %v", err)
+		}
+
+		hash := sha256.Sum256([]byte{byte(i)})
+		compressed := i%2 == 0
+
+		sig, err := SignCompact(curve, k.PrivateKey, hash[:], compressed)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+
+		publicKey, wasCompressed, err := RecoverCompact(curve, hash[:], sig)
+		if err != nil {
+			t.Fatalf("failed to recover public key: %v", err)
+		}
+
+		if publicKey.X.Cmp(k.PublicKey.X) != 0 || publicKey.Y.Cmp(k.PublicKey.Y) != 0 {
+			t.Fatalf("recovered public key: (%v, %v), expected: (%v, %v)",
+				publicKey.X, publicKey.Y, k.PublicKey.X, k.PublicKey.Y)
+		}
+
+		if wasCompressed != compressed {
+			t.Fatalf("expected compressed: %v, received: %v", compressed, wasCompressed)
+		}
+	}
+}
+
+// TestRecoverCompactInvalid will test that malformed compact signatures are
+// rejected.
+func TestRecoverCompactInvalid(t *testing.T) {
+	curve := secp256k1.New()
+	hash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+
+	if _, _, err := RecoverCompact(curve, hash[:], make([]byte, 64)); err == nil {
+		t.Fatalf("should have rejected a 64 byte signature")
+	}
+
+	sig := make([]byte, CompactSigLen)
+	sig[0] = 26
+	if _, _, err := RecoverCompact(curve, hash[:], sig); err == nil {
+		t.Fatalf("should have rejected an invalid header byte")
+	}
+
+	// R and S of zero are out of range.
+	sig[0] = 27
+	if _, _, err := RecoverCompact(curve, hash[:], sig); err == nil {
+		t.Fatalf("should have rejected a zero signature")
+	}
+}
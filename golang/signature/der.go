@@ -0,0 +1,219 @@
+package signature
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Errors returned by ParseDERSig, one for each BIP 66 strict encoding rule.
+var (
+	ErrDERTooShort       = errors.New("der signature is too short")
+	ErrDERTooLong        = errors.New("der signature is too long")
+	ErrDERNoSequence     = errors.New("der signature does not start with the sequence marker 0x30")
+	ErrDERBadLength      = errors.New("der signature length does not match the sequence length")
+	ErrDERRLengthTooLong = errors.New("der signature R length goes past the end of the signature")
+	ErrDERBadRSLength    = errors.New("der signature R and S lengths do not match the signature length")
+	ErrDERRNoInteger     = errors.New("der signature R is not marked as an integer 0x02")
+	ErrDERRZeroLength    = errors.New("der signature R has a length of zero")
+	ErrDERRNegative      = errors.New("der signature R is negative")
+	ErrDERRPadding       = errors.New("der signature R has excess padding")
+	ErrDERSNoInteger     = errors.New("der signature S is not marked as an integer 0x02")
+	ErrDERSZeroLength    = errors.New("der signature S has a length of zero")
+	ErrDERSNegative      = errors.New("der signature S is negative")
+	ErrDERSPadding       = errors.New("der signature S has excess padding")
+	ErrDERLaxInvalid     = errors.New("der signature could not be parsed")
+)
+
+// ParseDERSig will parse a DER formatted signature into a Signature,
+// enforcing the strict encoding rules of BIP 66. The signature must not
+// include the trailing sighash type byte.
+func ParseDERSig(sig []byte) (*Signature, error) {
+	// Signature Format:
+	// <DER><Length of signature><Marker for R><Length of R><R Value><Marker for S><Length of S><S Value>
+
+	// Minimum and maximum size constraints.
+	if len(sig) < 8 {
+		return nil, ErrDERTooShort
+	}
+	if len(sig) > 72 {
+		return nil, ErrDERTooLong
+	}
+
+	// A signature is of type 0x30 (compound).
+	if sig[0] != 0x30 {
+		return nil, ErrDERNoSequence
+	}
+
+	// Make sure the length covers the entire signature.
+	if int(sig[1]) != len(sig)-2 {
+		return nil, ErrDERBadLength
+	}
+
+	// Make sure the length of the S element is still inside the signature.
+	lenR := int(sig[3])
+	if 5+lenR >= len(sig) {
+		return nil, ErrDERRLengthTooLong
+	}
+
+	// Verify that the length of the signature matches the sum of the length
+	// of the elements.
+	lenS := int(sig[5+lenR])
+	if lenR+lenS+6 != len(sig) {
+		return nil, ErrDERBadRSLength
+	}
+
+	// Check whether the R element is an integer.
+	if sig[2] != 0x02 {
+		return nil, ErrDERRNoInteger
+	}
+
+	// Zero-length integers are not allowed for R.
+	if lenR == 0 {
+		return nil, ErrDERRZeroLength
+	}
+
+	// Negative numbers are not allowed for R.
+	if sig[4]&0x80 != 0 {
+		return nil, ErrDERRNegative
+	}
+
+	// Null bytes at the start of R are not allowed, unless R would otherwise
+	// be interpreted as a negative number.
+	if lenR > 1 && sig[4] == 0x00 && sig[5]&0x80 == 0 {
+		return nil, ErrDERRPadding
+	}
+
+	// Check whether the S element is an integer.
+	if sig[lenR+4] != 0x02 {
+		return nil, ErrDERSNoInteger
+	}
+
+	// Zero-length integers are not allowed for S.
+	if lenS == 0 {
+		return nil, ErrDERSZeroLength
+	}
+
+	// Negative numbers are not allowed for S.
+	if sig[lenR+6]&0x80 != 0 {
+		return nil, ErrDERSNegative
+	}
+
+	// Null bytes at the start of S are not allowed, unless S would otherwise
+	// be interpreted as a negative number.
+	if lenS > 1 && sig[lenR+6] == 0x00 && sig[lenR+7]&0x80 == 0 {
+		return nil, ErrDERSPadding
+	}
+
+	r := new(big.Int).SetBytes(sig[4 : 4+lenR])
+	s := new(big.Int).SetBytes(sig[6+lenR : 6+lenR+lenS])
+
+	return &Signature{R: r, S: s}, nil
+}
+
+// ParseDERSigLax will parse a DER formatted signature the way Bitcoin Core
+// did before BIP 66, for use with historic chain data. It tolerates long
+// form lengths, excess padding, negative values and trailing garbage. As in
+// Bitcoin Core, an R or S value that does not fit in 32 bytes is not an
+// error, instead both are set to zero so the signature never verifies.
+func ParseDERSigLax(sig []byte) (*Signature, error) {
+	pos := 0
+
+	// Sequence tag byte.
+	if pos == len(sig) || sig[pos] != 0x30 {
+		return nil, ErrDERLaxInvalid
+	}
+	pos++
+
+	// Sequence length bytes, which are skipped.
+	if pos == len(sig) {
+		return nil, ErrDERLaxInvalid
+	}
+	lenByte := int(sig[pos])
+	pos++
+	if lenByte&0x80 != 0 {
+		lenByte -= 0x80
+		if lenByte > len(sig)-pos {
+			return nil, ErrDERLaxInvalid
+		}
+		pos += lenByte
+	}
+
+	// Integer R.
+	rPos, rLen, pos, err := parseLaxInteger(sig, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	// Integer S.
+	sPos, sLen, _, err := parseLaxInteger(sig, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ignore leading zeroes in R and S.
+	for rLen > 0 && sig[rPos] == 0 {
+		rLen--
+		rPos++
+	}
+	for sLen > 0 && sig[sPos] == 0 {
+		sLen--
+		sPos++
+	}
+
+	// Values that overflow 32 bytes produce a signature that can never be
+	// valid.
+	if rLen > 32 || sLen > 32 {
+		return &Signature{R: new(big.Int), S: new(big.Int)}, nil
+	}
+
+	r := new(big.Int).SetBytes(sig[rPos : rPos+rLen])
+	s := new(big.Int).SetBytes(sig[sPos : sPos+sLen])
+
+	return &Signature{R: r, S: s}, nil
+}
+
+// parseLaxInteger will read an integer tag and length starting at pos,
+// returning the position and length of the integer's value and the position
+// after it.
+func parseLaxInteger(sig []byte, pos int) (int, int, int, error) {
+	// Integer tag byte.
+	if pos == len(sig) || sig[pos] != 0x02 {
+		return 0, 0, 0, ErrDERLaxInvalid
+	}
+	pos++
+
+	// Integer length.
+	if pos == len(sig) {
+		return 0, 0, 0, ErrDERLaxInvalid
+	}
+	lenByte := int(sig[pos])
+	pos++
+
+	var length int
+	if lenByte&0x80 != 0 {
+		lenByte -= 0x80
+		if lenByte > len(sig)-pos {
+			return 0, 0, 0, ErrDERLaxInvalid
+		}
+		for lenByte > 0 && sig[pos] == 0 {
+			pos++
+			lenByte--
+		}
+		if lenByte >= 8 {
+			return 0, 0, 0, ErrDERLaxInvalid
+		}
+		for lenByte > 0 {
+			length = (length << 8) + int(sig[pos])
+			pos++
+			lenByte--
+		}
+	} else {
+		length = lenByte
+	}
+
+	if length > len(sig)-pos {
+		return 0, 0, 0, ErrDERLaxInvalid
+	}
+
+	return pos, length, pos + length, nil
+}
@@ -0,0 +1,139 @@
+package signature
+
+import (
+	"encoding/hex"
+	"github.com/ccdle12/bitcoin-review/golang/utils"
+	"testing"
+)
+
+// TestParseDERSig will test that we can parse a DER signature taken from the
+// scriptSig of a mainnet transaction.
+func TestParseDERSig(t *testing.T) {
+	der, _ := hex.DecodeString("304402204585bcdef85e6b1c6af5c2669d4830ff86e42dd205c0e089bc2a821657e951c002201024a10366077f87d6bce1f7100ad8cfa8a064b39d4e8fe4ea13a7b71aa8180f")
+
+	sig, err := ParseDERSig(der)
+	if err != nil {
+		t.Fatalf("failed to parse der signature: %v", err)
+	}
+
+	expectedR := "4585bcdef85e6b1c6af5c2669d4830ff86e42dd205c0e089bc2a821657e951c0"
+	expectedS := "1024a10366077f87d6bce1f7100ad8cfa8a064b39d4e8fe4ea13a7b71aa8180f"
+	if hex.EncodeToString(sig.R.Bytes()) != expectedR {
+		t.Fatalf("expected R: %v, received: %x", expectedR, sig.R.Bytes())
+	}
+	if hex.EncodeToString(sig.S.Bytes()) != expectedS {
+		t.Fatalf("expected S: %v, received: %x", expectedS, sig.S.Bytes())
+	}
+}
+
+// TestParseDERSigRoundTrip will test that a signature encoded with
+// GenerateDERSig parses back to the same R and S.
+func TestParseDERSigRoundTrip(t *testing.T) {
+	r, _ := utils.ConvIntStrToBigInt("65768643913645672968978426589689987237850374542483501912088659345491159391021")
+	s, _ := utils.ConvIntStrToBigInt("55618899300744280687710599871980893657541124572884031214465422719409044157728")
+
+	sig := &Signature{R: r, S: s}
+	parsed, err := ParseDERSig(sig.GenerateDERSig())
+	if err != nil {
+		t.Fatalf("failed to parse der signature: %v", err)
+	}
+
+	if parsed.R.Cmp(r) != 0 || parsed.S.Cmp(s) != 0 {
+		t.Fatalf("expected: (%v, %v), received: (%v, %v)", r, s, parsed.R, parsed.S)
+	}
+}
+
+// TestParseDERSigStrictRules will test that each BIP 66 rule is reported
+// when it is violated.
+func TestParseDERSigStrictRules(t *testing.T) {
+	tests := []struct {
+		der      string
+		expected error
+	}{
+		{"30060201010201", ErrDERTooShort},
+		{"304a" + "0223" + "00" + repeatHex("01", 34) + "0223" + "00" + repeatHex("01", 34), ErrDERTooLong},
+		{"31060201010201010000", ErrDERNoSequence},
+		{"3007020101020101", ErrDERBadLength},
+		{"3006020401020101", ErrDERRLengthTooLong},
+		{"300602010102020101", ErrDERBadLength},
+		{"3007020101020201", ErrDERBadLength},
+		{"30060201010202010100", ErrDERBadLength},
+		{"3006030101020101", ErrDERRNoInteger},
+		{"3006020002020101", ErrDERRZeroLength},
+		{"3006020181020101", ErrDERRNegative},
+		{"300702020001020101", ErrDERRPadding},
+		{"3006020101030101", ErrDERSNoInteger},
+		{"30060201010200", ErrDERTooShort},
+		{"3006020101020181", ErrDERSNegative},
+		{"300702010102020001", ErrDERSPadding},
+	}
+
+	for _, test := range tests {
+		der, _ := hex.DecodeString(test.der)
+
+		_, err := ParseDERSig(der)
+		if err != test.expected {
+			t.Fatalf("der: %v, expected error: %v, received: %v", test.der, test.expected, err)
+		}
+	}
+}
+
+// TestParseDERSigLax will test that the lax parser accepts encodings that
+// predate BIP 66.
+func TestParseDERSigLax(t *testing.T) {
+	tests := []struct {
+		der string
+		r   int64
+		s   int64
+	}{
+		// Excess padding on R.
+		{"300702020001020102", 1, 2},
+		// Long form lengths.
+		{"3081080281010102810102", 1, 2},
+		// Negative S and trailing garbage.
+		{"3006020101020181ffff", 1, 0x81},
+	}
+
+	for _, test := range tests {
+		der, _ := hex.DecodeString(test.der)
+
+		sig, err := ParseDERSigLax(der)
+		if err != nil {
+			t.Fatalf("der: %v, failed to parse: %v", test.der, err)
+		}
+
+		if sig.R.Int64() != test.r || sig.S.Int64() != test.s {
+			t.Fatalf("der: %v, expected: (%v, %v), received: (%v, %v)",
+				test.der, test.r, test.s, sig.R, sig.S)
+		}
+
+		if _, err := ParseDERSig(der); err == nil {
+			t.Fatalf("der: %v, should have been rejected by the strict parser", test.der)
+		}
+	}
+
+	// R that overflows 32 bytes parses to a zero signature.
+	der, _ := hex.DecodeString("3026" + "0221" + "01" + repeatHex("00", 32) + "020101")
+	sig, err := ParseDERSigLax(der)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if sig.R.Sign() != 0 || sig.S.Sign() != 0 {
+		t.Fatalf("expected a zero signature, received: (%v, %v)", sig.R, sig.S)
+	}
+
+	// Missing the sequence marker is always invalid.
+	der, _ = hex.DecodeString("3106020101020101")
+	if _, err := ParseDERSigLax(der); err != ErrDERLaxInvalid {
+		t.Fatalf("expected error: %v, received: %v", ErrDERLaxInvalid, err)
+	}
+}
+
+func repeatHex(h string, n int) string {
+	var result string
+	for i := 0; i < n; i++ {
+		result += h
+	}
+
+	return result
+}
@@ -0,0 +1,134 @@
+package signature
+
+import (
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"math/big"
+)
+
+// Sign will generate an ECDSA signature of a 32-byte message hash using the
+// Private Key pk on the curve c. The nonce is derived deterministically
+// according to RFC 6979, so signing the same hash with the same key always
+// produces the same signature. The returned signature always has a low S.
+func Sign(c curve.Curve, pk *keys.PrivateKey, hash []byte) (*Signature, error) {
+	sig, _, err := signRecoverable(c, pk, hash)
+
+	return sig, err
+}
+
+// signRecoverable will generate a low S signature as Sign does, also
+// returning the recovery id needed to recover the Public Key from it. Bit 0 of
+// the recovery id is set if the y co-ordinate of R is odd and bit 1 is set if
+// the x co-ordinate of R was greater than or equal to N.
+func signRecoverable(c curve.Curve, pk *keys.PrivateKey, hash []byte) (*Signature, byte, error) {
+	if len(hash) != 32 {
+		return nil, 0, errors.New("message hash must be 32 bytes")
+	}
+
+	params := c.Params()
+	secret := pk.Secret()
+
+	// z is the message hash as an integer.
+	z := new(big.Int).SetBytes(hash)
+
+	nonces := newNonceGenerator(params.N, secret, hash)
+	for {
+		k := nonces.next()
+
+		// R = k*G, r is the x co-ordinate of R mod N.
+		x, y := c.AffineFromJacobian(c.ScalarBaseMult(k.Bytes()))
+		r := new(big.Int).Mod(x, params.N)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		// s = k^-1 * (z + r*secret) mod N
+		kInv := inverseModN(c, k)
+		s := new(big.Int).Mul(r, secret)
+		s.Add(s, z)
+		s.Mul(s, kInv)
+		s.Mod(s, params.N)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		var recoveryID byte
+		if y.Bit(0) == 1 {
+			recoveryID |= 1
+		}
+		if x.Cmp(params.N) >= 0 {
+			recoveryID |= 2
+		}
+
+		// Negating S corresponds to negating R, which flips the parity of
+		// its y co-ordinate.
+		sig := &Signature{R: r, S: s}
+		if sig.NormalizeS(c) {
+			recoveryID ^= 1
+		}
+
+		return sig, recoveryID, nil
+	}
+}
+
+// inverseModN returns k^-1 mod N, in constant time if the curve supports it.
+func inverseModN(c curve.Curve, k *big.Int) *big.Int {
+	if inverter, ok := c.(curve.ScalarInverter); ok {
+		return inverter.ScalarInverse(k)
+	}
+
+	return new(big.Int).ModInverse(k, c.Params().N)
+}
+
+// scalarMultPublic returns k*(x, y) for a public scalar k, using the curve's
+// variable time multiplication if it has one.
+func scalarMultPublic(c curve.Curve, x, y *big.Int, k []byte) (*big.Int, *big.Int, *big.Int) {
+	if multiplier, ok := c.(curve.VartimeScalarMulter); ok {
+		return multiplier.ScalarMultVartime(x, y, k)
+	}
+
+	return c.ScalarMult(x, y, k)
+}
+
+// Verify will check that sig is a valid ECDSA signature of the 32-byte
+// message hash by the Public Key pub on the curve c.
+func Verify(c curve.Curve, pub *keys.PublicKey, hash []byte, sig *Signature) bool {
+	params := c.Params()
+
+	// R and S must both be in the range [1, N).
+	if sig.R.Sign() <= 0 || sig.R.Cmp(params.N) >= 0 {
+		return false
+	}
+	if sig.S.Sign() <= 0 || sig.S.Cmp(params.N) >= 0 {
+		return false
+	}
+
+	if !c.IsOnCurve(pub.X, pub.Y) {
+		return false
+	}
+
+	// z is the message hash as an integer.
+	z := new(big.Int).SetBytes(hash)
+
+	// u1 = z/s mod N and u2 = r/s mod N.
+	sInv := new(big.Int).ModInverse(sig.S, params.N)
+	u1 := new(big.Int).Mul(z, sInv)
+	u1.Mod(u1, params.N)
+	u2 := new(big.Int).Mul(sig.R, sInv)
+	u2.Mod(u2, params.N)
+
+	// Compute u1*G + u2*Q, which must not be the point at infinity.
+	x1, y1, z1 := c.ScalarBaseMult(u1.Bytes())
+	x2, y2, z2 := scalarMultPublic(c, pub.X, pub.Y, u2.Bytes())
+	x3, y3, z3 := c.JacobianAdd(x1, y1, z1, x2, y2, z2)
+	if z3.Sign() == 0 {
+		return false
+	}
+
+	// The signature is valid if the x co-ordinate mod N is equal to R.
+	x, _ := c.AffineFromJacobian(x3, y3, z3)
+	x.Mod(x, params.N)
+
+	return x.Cmp(sig.R) == 0
+}
@@ -0,0 +1,206 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"github.com/ccdle12/bitcoin-review/golang/p256"
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"math/big"
+	"testing"
+)
+
+// TestSign will test that signing with a known Private Key produces the
+// expected deterministic signature.
+func TestSign(t *testing.T) {
+	curve := secp256k1.New()
+
+	privateKey, err := keys.PrivateKeyFromBytes(curve, []byte{0x01})
+	if err != nil {
+		t.Fatalf("failed to create private key: %v", err)
+	}
+
+	tests := []struct {
+		message string
+		r       string
+		s       string
+	}{
+		{
+			"Satoshi Nakamoto",
+			"934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8",
+			"2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+		},
+		{
+			"All those moments will be lost in time, like tears in rain. Time to die...",
+			"8600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b",
+			"547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc21",
+		},
+	}
+
+	for _, test := range tests {
+		hash := sha256.Sum256([]byte(test.message))
+
+		sig, err := Sign(curve, privateKey, hash[:])
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+
+		r := fmt.Sprintf("%064x", sig.R)
+		s := fmt.Sprintf("%064x", sig.S)
+		if r != test.r || s != test.s {
+			t.Fatalf("message: %v, expected: (%v, %v), received: (%v, %v)",
+				test.message, test.r, test.s, r, s)
+		}
+
+		// Blinding must not change the deterministic signature.
+		blinded, err := Sign(secp256k1.NewBlinded(), privateKey, hash[:])
+		if err != nil {
+			t.Fatalf("failed to sign with blinding: %v", err)
+		}
+		if blinded.R.Cmp(sig.R) != 0 || blinded.S.Cmp(sig.S) != 0 {
+			t.Fatalf("message: %v, blinded signature does not match: (%x, %x)",
+				test.message, blinded.R, blinded.S)
+		}
+	}
+}
+
+// TestSignInvalidHash will test that we reject hashes that are not 32 bytes.
+func TestSignInvalidHash(t *testing.T) {
+	curve := secp256k1.New()
+
+	k, err := keys.New(curve)
+	if err != nil {
+		t.Fatalf("failed to generate keys: %v", err)
+	}
+
+	if _, err := Sign(curve, k.PrivateKey, []byte{0x01, 0x02}); err == nil {
+		t.Fatalf("should have rejected a short hash")
+	}
+}
+
+// TestSignAndVerify will test that a generated signature verifies against
+// the signer's Public Key and fails for a different message or key.
+func TestSignAndVerify(t *testing.T) {
+	curve := secp256k1.New()
+
+	k, err := keys.New(curve)
+	if err != nil {
+		t.Fatalf("failed to generate keys: %v", err)
+	}
+
+	hash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+	sig, err := Sign(curve, k.PrivateKey, hash[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	if !Verify(curve, k.PublicKey, hash[:], sig) {
+		t.Fatalf("failed to verify a valid signature")
+	}
+
+	// New signatures should always be low S.
+	if sig.IsHighS(curve) {
+		t.Fatalf("expected a low S signature, received S: %v", sig.S)
+	}
+
+	// A different message should fail.
+	other := sha256.Sum256([]byte("Satoshi Nakamoto!"))
+	if Verify(curve, k.PublicKey, other[:], sig) {
+		t.Fatalf("should have failed to verify a different message")
+	}
+
+	// A different Public Key should fail.
+	k2, err := keys.New(curve)
+	if err != nil {
+		t.Fatalf("failed to generate keys: %v", err)
+	}
+	if Verify(curve, k2.PublicKey, hash[:], sig) {
+		t.Fatalf("should have failed to verify with a different public key")
+	}
+}
+
+// TestVerifyOutOfRange will test that signatures with R or S outside of
+// [1, N) are rejected.
+func TestVerifyOutOfRange(t *testing.T) {
+	curve := secp256k1.New()
+
+	k, err := keys.New(curve)
+	if err != nil {
+		t.Fatalf("failed to generate keys: %v", err)
+	}
+
+	hash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+	sig, err := Sign(curve, k.PrivateKey, hash[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	tests := []*Signature{
+		{R: big.NewInt(0), S: sig.S},
+		{R: sig.R, S: big.NewInt(0)},
+		{R: new(big.Int).Add(sig.R, curve.N), S: sig.S},
+		{R: sig.R, S: new(big.Int).Add(sig.S, curve.N)},
+	}
+
+	for _, test := range tests {
+		if Verify(curve, k.PublicKey, hash[:], test) {
+			t.Fatalf("should have rejected signature: (%v, %v)", test.R, test.S)
+		}
+	}
+}
+
+// TestP256CrossCheck will test that DER signatures made on P-256 verify with
+// crypto/ecdsa, and that signatures made by crypto/ecdsa verify here.
+func TestP256CrossCheck(t *testing.T) {
+	curve := p256.New()
+
+	for i := 0; i < 10; i++ {
+		hash := sha256.Sum256([]byte(fmt.Sprintf("message %v", i)))
+
+		// Sign here and verify with crypto/ecdsa.
+		k, err := keys.New(curve)
+		if err != nil {
+			t.Fatalf("failed to generate keys: %v", err)
+		}
+		sig, err := Sign(curve, k.PrivateKey, hash[:])
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: k.PublicKey.X, Y: k.PublicKey.Y}
+		if !ecdsa.VerifyASN1(pub, hash[:], sig.GenerateDERSig()) {
+			t.Fatalf("crypto/ecdsa failed to verify signature: (%x, %x)", sig.R, sig.S)
+		}
+
+		// Sign with crypto/ecdsa and verify here.
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("failed to generate a crypto/ecdsa key: %v", err)
+		}
+		der, err := ecdsa.SignASN1(rand.Reader, priv, hash[:])
+		if err != nil {
+			t.Fatalf("failed to sign with crypto/ecdsa: %v", err)
+		}
+
+		parsed, err := ParseDERSig(der)
+		if err != nil {
+			t.Fatalf("failed to parse crypto/ecdsa signature: %x, %v", der, err)
+		}
+		publicKey, err := keys.NewPublicKey(curve, priv.X, priv.Y)
+		if err != nil {
+			t.Fatalf("failed to create public key: %v", err)
+		}
+		if !Verify(curve, publicKey, hash[:], parsed) {
+			t.Fatalf("failed to verify crypto/ecdsa signature: %x", der)
+		}
+
+		// A different message must not verify.
+		other := sha256.Sum256([]byte("other"))
+		if Verify(curve, publicKey, other[:], parsed) {
+			t.Fatalf("verified a signature for the wrong message")
+		}
+	}
+}
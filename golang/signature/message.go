@@ -0,0 +1,146 @@
+package signature
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"github.com/ccdle12/bitcoin-review/golang/utils"
+)
+
+// messageMagic is prepended to every message before hashing, so a signed
+// message can never be a valid transaction signature.
+const messageMagic = "Bitcoin Signed Message:\n"
+
+// MessageAddressType is the type of address a signed message is for. Its
+// value is the BIP 137 header byte for a recovery id of 0.
+type MessageAddressType byte
+
+const (
+	// MessageP2PKHUncompressed is a P2PKH address of an uncompressed key.
+	MessageP2PKHUncompressed MessageAddressType = 27
+
+	// MessageP2PKHCompressed is a P2PKH address of a compressed key.
+	MessageP2PKHCompressed MessageAddressType = 31
+
+	// MessageP2SHP2WPKH is a P2SH-P2WPKH (nested segwit) address.
+	MessageP2SHP2WPKH MessageAddressType = 35
+
+	// MessageP2WPKH is a P2WPKH (native segwit) address.
+	MessageP2WPKH MessageAddressType = 39
+)
+
+// HashMessage returns the double SHA256 of the message prefixed with the
+// Bitcoin Signed Message magic, each prefixed with its varint length.
+func HashMessage(message string) ([]byte, error) {
+	magicLen, err := utils.EncodeVarint(len(messageMagic))
+	if err != nil {
+		return nil, err
+	}
+	messageLen, err := utils.EncodeVarint(len(message))
+	if err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	b = append(b, magicLen...)
+	b = append(b, messageMagic...)
+	b = append(b, messageLen...)
+	b = append(b, message...)
+
+	return utils.DoubleSHA256(b), nil
+}
+
+// SignMessage will sign the message with the Private Key pk, returning the
+// base64 encoded compact signature with the BIP 137 header byte for the
+// address type.
+func SignMessage(c curve.Curve, pk *keys.PrivateKey, message string, addressType MessageAddressType) (string, error) {
+	switch addressType {
+	case MessageP2PKHUncompressed, MessageP2PKHCompressed, MessageP2SHP2WPKH, MessageP2WPKH:
+	default:
+		return "", errors.New("unknown message address type")
+	}
+
+	hash, err := HashMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	sig, recoveryID, err := signRecoverable(c, pk, hash)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(sig.compact(byte(addressType) + recoveryID)), nil
+}
+
+// VerifyMessage will check that the base64 encoded signature of the message
+// was produced by the key of address. The address can be a mainnet or
+// testnet P2PKH, P2SH-P2WPKH or P2WPKH address, matching the header byte of
+// the signature.
+func VerifyMessage(c curve.Curve, address, signature, message string) (bool, error) {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false, errors.New("signature is not valid base64")
+	}
+	if len(sig) != CompactSigLen {
+		return false, errors.New("signature must be 65 bytes")
+	}
+
+	header := sig[0]
+	if header < byte(MessageP2PKHUncompressed) || header > byte(MessageP2WPKH)+3 {
+		return false, errors.New("invalid signature header byte")
+	}
+	addressType := MessageAddressType(header - (header-27)%4)
+
+	hash, err := HashMessage(message)
+	if err != nil {
+		return false, err
+	}
+
+	// Recover the Public Key with the recovery id in the header byte, the
+	// address type tells us how it is serialized.
+	s, err := ParseCompact(sig)
+	if err != nil {
+		return false, err
+	}
+	publicKey, err := RecoverPublicKey(c, hash, s, (header-27)%4)
+	if err != nil {
+		return false, err
+	}
+
+	var candidates []string
+	switch addressType {
+	case MessageP2PKHUncompressed:
+		sec := publicKey.UncompressedSEC()
+		candidates = append(candidates, keys.GenerateMainnetAddress(sec), keys.GenerateTestnetAddress(sec))
+
+	case MessageP2PKHCompressed:
+		sec := publicKey.CompressedSEC()
+		candidates = append(candidates, keys.GenerateMainnetAddress(sec), keys.GenerateTestnetAddress(sec))
+
+	case MessageP2SHP2WPKH:
+		sec := publicKey.CompressedSEC()
+		candidates = append(candidates, keys.GenerateMainnetP2SHP2WPKHAddress(sec), keys.GenerateTestnetP2SHP2WPKHAddress(sec))
+
+	case MessageP2WPKH:
+		sec := publicKey.CompressedSEC()
+		mainnet, err := keys.GenerateMainnetP2WPKHAddress(sec)
+		if err != nil {
+			return false, err
+		}
+		testnet, err := keys.GenerateTestnetP2WPKHAddress(sec)
+		if err != nil {
+			return false, err
+		}
+		candidates = append(candidates, mainnet, testnet)
+	}
+
+	for _, candidate := range candidates {
+		if candidate == address {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,132 @@
+package signature
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"testing"
+)
+
+// TestSignMessage will test that signing a message with a known key produces
+// the expected signature, which verifies against the key's address.
+func TestSignMessage(t *testing.T) {
+	curve := secp256k1.New()
+
+	// Private Key of the WIF L4rK1yDtCWekvXuE6oXD9jCYfFNV2cWRpVuPLBcCU2z8TrisoyY1.
+	secret, _ := hex.DecodeString("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	privateKey, err := keys.PrivateKeyFromBytes(curve, secret)
+	if err != nil {
+		t.Fatalf("failed to create private key: %v", err)
+	}
+
+	message := "This is an example of a signed message."
+	expected := "H9L5yLFjti0QTHhPyFrZCT1V/MMnBtXKmoiKDZ78NDBjERki6ZTQZdSMCtkgoNmp17By9ItJr8o7ChX0XxY91nk="
+
+	sig, err := SignMessage(curve, privateKey, message, MessageP2PKHCompressed)
+	if err != nil {
+		t.Fatalf("failed to sign message: %v", err)
+	}
+
+	if sig != expected {
+		t.Fatalf("expected: %v, received: %v", expected, sig)
+	}
+
+	valid, err := VerifyMessage(curve, "1F3sAm6ZtwLAUnj7d38pGFxtP3RVEvtsbV", sig, message)
+	if err != nil || !valid {
+		t.Fatalf("failed to verify message: %v", err)
+	}
+
+	// A different message should fail.
+	valid, err = VerifyMessage(curve, "1F3sAm6ZtwLAUnj7d38pGFxtP3RVEvtsbV", sig, message+"!")
+	if err != nil || valid {
+		t.Fatalf("should have failed to verify a different message: %v", err)
+	}
+}
+
+// TestSignMessageAddressTypes will test that each header byte variant
+// verifies against the matching mainnet and testnet addresses only.
+func TestSignMessageAddressTypes(t *testing.T) {
+	curve := secp256k1.New()
+
+	k, err := keys.New(curve)
+	if err != nil {
+		t.Fatalf("failed to generate keys: %v", err)
+	}
+
+	compressed := k.PublicKey.CompressedSEC()
+	uncompressed := k.PublicKey.UncompressedSEC()
+	p2wpkhMainnet, _ := keys.GenerateMainnetP2WPKHAddress(compressed)
+	p2wpkhTestnet, _ := keys.GenerateTestnetP2WPKHAddress(compressed)
+
+	tests := []struct {
+		addressType MessageAddressType
+		addresses   []string
+		header      byte
+	}{
+		{
+			MessageP2PKHUncompressed,
+			[]string{keys.GenerateMainnetAddress(uncompressed), keys.GenerateTestnetAddress(uncompressed)},
+			27,
+		},
+		{
+			MessageP2PKHCompressed,
+			[]string{keys.GenerateMainnetAddress(compressed), keys.GenerateTestnetAddress(compressed)},
+			31,
+		},
+		{
+			MessageP2SHP2WPKH,
+			[]string{keys.GenerateMainnetP2SHP2WPKHAddress(compressed), keys.GenerateTestnetP2SHP2WPKHAddress(compressed)},
+			35,
+		},
+		{
+			MessageP2WPKH,
+			[]string{p2wpkhMainnet, p2wpkhTestnet},
+			39,
+		},
+	}
+
+	message := "ownership proof"
+	for _, test := range tests {
+		sig, err := SignMessage(curve, k.PrivateKey, message, test.addressType)
+		if err != nil {
+			t.Fatalf("failed to sign message: %v", err)
+		}
+
+		raw, _ := base64.StdEncoding.DecodeString(sig)
+		if raw[0] < test.header || raw[0] > test.header+3 {
+			t.Fatalf("expected a header byte between %v and %v, received: %v", test.header, test.header+3, raw[0])
+		}
+
+		for _, address := range test.addresses {
+			valid, err := VerifyMessage(curve, address, sig, message)
+			if err != nil || !valid {
+				t.Fatalf("failed to verify message for address: %v, %v", address, err)
+			}
+		}
+
+		// The address of a different type must not verify.
+		other := keys.GenerateMainnetAddress(uncompressed)
+		if test.addressType == MessageP2PKHUncompressed {
+			other = keys.GenerateMainnetAddress(compressed)
+		}
+		valid, err := VerifyMessage(curve, other, sig, message)
+		if err != nil || valid {
+			t.Fatalf("should have failed to verify for address: %v, %v", other, err)
+		}
+	}
+}
+
+// TestVerifyMessageInvalid will test that malformed signatures are rejected.
+func TestVerifyMessageInvalid(t *testing.T) {
+	curve := secp256k1.New()
+	address := "1F3sAm6ZtwLAUnj7d38pGFxtP3RVEvtsbV"
+
+	if _, err := VerifyMessage(curve, address, "not base64!", "message"); err == nil {
+		t.Fatalf("should have rejected invalid base64")
+	}
+
+	if _, err := VerifyMessage(curve, address, "AAAA", "message"); err == nil {
+		t.Fatalf("should have rejected a short signature")
+	}
+}
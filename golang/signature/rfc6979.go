@@ -0,0 +1,112 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+)
+
+// nonceGenerator generates deterministic nonces for ECDSA as described in
+// RFC 6979 section 3.2, using HMAC-SHA256.
+type nonceGenerator struct {
+	n       *big.Int
+	k       []byte
+	v       []byte
+	started bool
+}
+
+// newNonceGenerator is the constructor for the nonceGenerator, given the
+// curve order n, the private key secret and the hash of the message.
+func newNonceGenerator(n, secret *big.Int, hash []byte) *nonceGenerator {
+	// Step b and c: V = 0x01 0x01 ... and K = 0x00 0x00 ...
+	v := make([]byte, sha256.Size)
+	for i := range v {
+		v[i] = 0x01
+	}
+	k := make([]byte, sha256.Size)
+
+	g := &nonceGenerator{n: n, k: k, v: v}
+
+	x := g.int2octets(secret)
+	h := g.bits2octets(hash)
+
+	// Step d: K = HMAC_K(V || 0x00 || int2octets(x) || bits2octets(h1))
+	g.k = g.mac(g.k, g.v, []byte{0x00}, x, h)
+	// Step e: V = HMAC_K(V)
+	g.v = g.mac(g.k, g.v)
+	// Step f: K = HMAC_K(V || 0x01 || int2octets(x) || bits2octets(h1))
+	g.k = g.mac(g.k, g.v, []byte{0x01}, x, h)
+	// Step g: V = HMAC_K(V)
+	g.v = g.mac(g.k, g.v)
+
+	return g
+}
+
+// next will return the next candidate nonce in the range [1, n). Calling it
+// again after a nonce was rejected continues the generation as step h.3
+// describes.
+func (g *nonceGenerator) next() *big.Int {
+	if g.started {
+		g.k = g.mac(g.k, g.v, []byte{0x00})
+		g.v = g.mac(g.k, g.v)
+	}
+	g.started = true
+
+	qlen := g.n.BitLen()
+	for {
+		// Step h.2: fill T with HMAC_K(V) until it has at least qlen bits.
+		var t []byte
+		for len(t)*8 < qlen {
+			g.v = g.mac(g.k, g.v)
+			t = append(t, g.v...)
+		}
+
+		// Step h.3: return k if it is in the range [1, n).
+		k := g.bits2int(t)
+		if k.Sign() > 0 && k.Cmp(g.n) < 0 {
+			return k
+		}
+
+		g.k = g.mac(g.k, g.v, []byte{0x00})
+		g.v = g.mac(g.k, g.v)
+	}
+}
+
+// mac returns HMAC-SHA256 keyed with key over the concatenation of data.
+func (g *nonceGenerator) mac(key []byte, data ...[]byte) []byte {
+	h := hmac.New(sha256.New, key)
+	for _, d := range data {
+		h.Write(d)
+	}
+
+	return h.Sum(nil)
+}
+
+// bits2int converts a byte slice to an integer, keeping only the leftmost
+// qlen bits.
+func (g *nonceGenerator) bits2int(b []byte) *big.Int {
+	x := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - g.n.BitLen(); excess > 0 {
+		x.Rsh(x, uint(excess))
+	}
+
+	return x
+}
+
+// int2octets converts an integer to a big-endian byte slice of rlen bytes.
+func (g *nonceGenerator) int2octets(x *big.Int) []byte {
+	rlen := (g.n.BitLen() + 7) / 8
+	out := make([]byte, rlen)
+	xb := x.Bytes()
+	copy(out[rlen-len(xb):], xb)
+
+	return out
+}
+
+// bits2octets converts a hash to an integer modulo n and back to rlen bytes.
+func (g *nonceGenerator) bits2octets(b []byte) []byte {
+	z := g.bits2int(b)
+	z.Mod(z, g.n)
+
+	return g.int2octets(z)
+}
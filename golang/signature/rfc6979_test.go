@@ -0,0 +1,62 @@
+package signature
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
+	"math/big"
+	"testing"
+)
+
+// TestRFC6979Nonce will test that we generate the expected deterministic
+// nonces for known secp256k1 private keys and messages.
+func TestRFC6979Nonce(t *testing.T) {
+	curve := secp256k1.New()
+
+	tests := []struct {
+		secret   *big.Int
+		message  string
+		expected string
+	}{
+		{
+			big.NewInt(1),
+			"Satoshi Nakamoto",
+			"8F8A276C19F4149656B280621E358CCE24F5F52542772691EE69063B74F15D15",
+		},
+		{
+			big.NewInt(1),
+			"All those moments will be lost in time, like tears in rain. Time to die...",
+			"38AA22D72376B4DBC472E06C3BA403EE0A394DA63FC58D88686C611ABA98D6B3",
+		},
+		{
+			new(big.Int).Sub(curve.N, big.NewInt(1)),
+			"Satoshi Nakamoto",
+			"33A19B60E25FB6F4435AF53A3D42D493644827367E6453928554F43E49AA6F90",
+		},
+	}
+
+	for _, test := range tests {
+		hash := sha256.Sum256([]byte(test.message))
+		k := newNonceGenerator(curve.N, test.secret, hash[:]).next()
+
+		if fmt.Sprintf("%064X", k) != test.expected {
+			t.Fatalf("message: %v, expected nonce: %v, received: %064X",
+				test.message, test.expected, k)
+		}
+	}
+}
+
+// TestRFC6979NonceRetry will test that asking for another nonce produces a
+// different value.
+func TestRFC6979NonceRetry(t *testing.T) {
+	curve := secp256k1.New()
+	hash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+
+	nonces := newNonceGenerator(curve.N, big.NewInt(1), hash[:])
+	k1 := nonces.next()
+	k2 := nonces.next()
+
+	if k1.Cmp(k2) == 0 {
+		t.Fatalf("expected a different nonce on retry, received: %v", k2)
+	}
+}
@@ -0,0 +1,140 @@
+package signature
+
+import (
+	"crypto/rand"
+	"errors"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
+	"github.com/ccdle12/bitcoin-review/golang/utils"
+	"math/big"
+)
+
+// SchnorrSigLen is the length of a BIP 340 Schnorr signature, the 32 byte x
+// co-ordinate of R followed by the 32 byte s.
+const SchnorrSigLen = 64
+
+// SignSchnorr will generate a BIP 340 Schnorr signature of msg with the
+// Private Key pk. auxRand is 32 bytes of auxiliary randomness mixed into the
+// nonce, if it is nil 32 bytes are read from crypto/rand.
+func SignSchnorr(c curve.Curve, pk *keys.PrivateKey, msg, auxRand []byte) ([]byte, error) {
+	if auxRand == nil {
+		auxRand = make([]byte, 32)
+		if _, err := rand.Read(auxRand); err != nil {
+			return nil, errors.New("failed to read auxiliary randomness")
+		}
+	}
+	if len(auxRand) != 32 {
+		return nil, errors.New("auxiliary randomness must be 32 bytes")
+	}
+
+	params := c.Params()
+
+	// P = d'*G, negate d' if P has an odd y so that P is the x-only key.
+	d := pk.Secret()
+	px, py := c.AffineFromJacobian(c.ScalarBaseMult(d.Bytes()))
+	if py.Bit(0) == 1 {
+		d.Sub(params.N, d)
+	}
+	pBytes := intTo32Bytes(px)
+
+	// t = bytes(d) xor hash_BIP0340/aux(a)
+	t := intTo32Bytes(d)
+	auxHash := utils.TaggedHash("BIP0340/aux", auxRand)
+	for i := range t {
+		t[i] ^= auxHash[i]
+	}
+
+	// k' = int(hash_BIP0340/nonce(t || bytes(P) || m)) mod n
+	nonce := utils.TaggedHash("BIP0340/nonce", t, pBytes, msg)
+	k := new(big.Int).SetBytes(nonce)
+	k.Mod(k, params.N)
+	if k.Sign() == 0 {
+		return nil, errors.New("generated nonce is zero")
+	}
+
+	// R = k'*G, negate k' if R has an odd y.
+	rx, ry := c.AffineFromJacobian(c.ScalarBaseMult(k.Bytes()))
+	if ry.Bit(0) == 1 {
+		k.Sub(params.N, k)
+	}
+	rBytes := intTo32Bytes(rx)
+
+	// e = int(hash_BIP0340/challenge(bytes(R) || bytes(P) || m)) mod n
+	e := schnorrChallenge(params.N, rBytes, pBytes, msg)
+
+	// s = (k + e*d) mod n
+	s := new(big.Int).Mul(e, d)
+	s.Add(s, k)
+	s.Mod(s, params.N)
+
+	sig := append(rBytes, intTo32Bytes(s)...)
+
+	// Verify the signature before returning it, to catch faults.
+	if !VerifySchnorr(c, pBytes, msg, sig) {
+		return nil, errors.New("created schnorr signature failed verification")
+	}
+
+	return sig, nil
+}
+
+// VerifySchnorr will check that sig is a valid BIP 340 Schnorr signature of
+// msg by the 32 byte x-only Public Key pubKey.
+func VerifySchnorr(c curve.Curve, pubKey, msg, sig []byte) bool {
+	if len(sig) != SchnorrSigLen {
+		return false
+	}
+
+	params := c.Params()
+
+	// P = lift_x(int(pk))
+	publicKey, err := keys.PublicKeyFromXOnly(c, pubKey)
+	if err != nil {
+		return false
+	}
+
+	// r must be a field element and s must be less than the order.
+	r := new(big.Int).SetBytes(sig[:32])
+	if r.Cmp(params.P) >= 0 {
+		return false
+	}
+	s := new(big.Int).SetBytes(sig[32:])
+	if s.Cmp(params.N) >= 0 {
+		return false
+	}
+
+	e := schnorrChallenge(params.N, sig[:32], pubKey, msg)
+
+	// R = s*G - e*P = s*G + (n-e)*P
+	negE := new(big.Int).Sub(params.N, e)
+	negE.Mod(negE, params.N)
+	x1, y1, z1 := c.ScalarBaseMult(s.Bytes())
+	x2, y2, z2 := scalarMultPublic(c, publicKey.X, publicKey.Y, negE.Bytes())
+	rx, ry, rz := c.JacobianAdd(x1, y1, z1, x2, y2, z2)
+
+	// R must not be infinity, must have an even y and x equal to r.
+	if rz.Sign() == 0 {
+		return false
+	}
+	x, y := c.AffineFromJacobian(rx, ry, rz)
+	if y.Bit(0) == 1 {
+		return false
+	}
+
+	return x.Cmp(r) == 0
+}
+
+// schnorrChallenge returns int(hash_BIP0340/challenge(r || p || m)) mod n.
+func schnorrChallenge(n *big.Int, r, p, msg []byte) *big.Int {
+	e := new(big.Int).SetBytes(utils.TaggedHash("BIP0340/challenge", r, p, msg))
+
+	return e.Mod(e, n)
+}
+
+// intTo32Bytes returns x as a 32 byte big-endian slice.
+func intTo32Bytes(x *big.Int) []byte {
+	b := make([]byte, 32)
+	xb := x.Bytes()
+	copy(b[32-len(xb):], xb)
+
+	return b
+}
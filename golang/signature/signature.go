@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"github.com/ccdle12/bitcoin-review/golang/curve"
 	"math/big"
 )
 
@@ -27,7 +28,7 @@ func (sig *Signature) GenerateDERSig() []byte {
 	result := []byte{}
 
 	// Check if rbin has a high bit.
-	if rbin[0] > 128 {
+	if rbin[0] >= 0x80 {
 		rbin = append([]byte{0x00}, rbin...)
 	}
 
@@ -45,7 +46,7 @@ func (sig *Signature) GenerateDERSig() []byte {
 	fmt.Printf("result after append: %x\n", result)
 
 	// Check if sbin has a high bit.
-	if sbin[0] > 128 {
+	if sbin[0] >= 0x80 {
 		sbin = append([]byte{0x00}, sbin...)
 	}
 
@@ -67,6 +68,26 @@ func (sig *Signature) GenerateDERSig() []byte {
 	return result
 }
 
+// IsHighS reports whether S is in the upper half of the order of the curve c.
+// Signatures with a high S are non-standard under BIP 62 and BIP 146, since
+// (R, N-S) is also a valid signature for the same message.
+func (sig *Signature) IsHighS(c curve.Curve) bool {
+	halfOrder := new(big.Int).Rsh(c.Params().N, 1)
+
+	return sig.S.Cmp(halfOrder) > 0
+}
+
+// NormalizeS will replace a high S with N-S so the signature is in the lower
+// half of the order of the curve c. It returns true if S was changed.
+func (sig *Signature) NormalizeS(c curve.Curve) bool {
+	if !sig.IsHighS(c) {
+		return false
+	}
+	sig.S = new(big.Int).Sub(c.Params().N, sig.S)
+
+	return true
+}
+
 func convIntToTrimmedByte(i int) []byte {
 	b := make([]byte, 4)
 	binary.BigEndian.PutUint32(b, uint32(i))
@@ -3,6 +3,7 @@ package signature
 import (
 	// "crypto/ecdsa"
 	"fmt"
+	"github.com/ccdle12/bitcoin-review/golang/keys"
 	"github.com/ccdle12/bitcoin-review/golang/secp256k1"
 	"github.com/ccdle12/bitcoin-review/golang/utils"
 	"math/big"
@@ -80,11 +81,11 @@ func TestValidSignature(t *testing.T) {
 	v1.Mod(v1, curve.N)
 	fmt.Printf("sig: %v\n", v1)
 
-	x2, y2, z2 := curve.ScalarMult(u1.Bytes())
+	x2, y2, z2 := curve.ScalarBaseMult(u1.Bytes())
 	// aX2, aY2 := curve.AffineFromJacobian(x2, y2, z2)
 	// fmt.Printf("sig x2: %v\n %v\n %v\n", x2, y2, z2)
 
-	x3, y3, z3 := curve.GenericScalarMult(px, py, v1.Bytes())
+	x3, y3, z3 := curve.ScalarMult(px, py, v1.Bytes())
 	// aX3, aY3 := curve.AffineFromJacobian(x3, y3, z3)
 	fmt.Printf("sig x3: %v\n %v\n %v\n", x3, y3, z3)
 
@@ -99,4 +100,57 @@ func TestValidSignature(t *testing.T) {
 	fmt.Printf("result: resultx %v\n", resultX)
 	fmt.Printf("result: r1 %v\n", r1)
 	fmt.Printf("eq: %v\n", eq)
+	if eq != 0 {
+		t.Fatalf("resultX: %v does not match r1: %v", resultX, r1)
+	}
+
+	// The signature should also pass Verify.
+	pub, err := keys.NewPublicKey(curve, px, py)
+	if err != nil {
+		t.Fatalf("failed to create a public key: %v", err)
+	}
+	if !Verify(curve, pub, z1.Bytes(), &Signature{R: r1, S: s1}) {
+		t.Fatalf("failed to verify a valid signature")
+	}
+}
+
+// TestNormalizeS will test that a high S is detected and normalized to the
+// lower half of the curve order, and that both forms verify.
+func TestNormalizeS(t *testing.T) {
+	curve := secp256k1.New()
+
+	k, err := keys.New(curve)
+	if err != nil {
+		t.Fatalf("failed to generate keys: %v", err)
+	}
+
+	hash := utils.DoubleSHA256([]byte("Satoshi Nakamoto"))
+	sig, err := Sign(curve, k.PrivateKey, hash)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	// Low S should not be changed.
+	if sig.NormalizeS(curve) {
+		t.Fatalf("should not have normalized a low S")
+	}
+
+	// Create the high S form of the signature.
+	highS := &Signature{R: sig.R, S: new(big.Int).Sub(curve.N, sig.S)}
+	if !highS.IsHighS(curve) {
+		t.Fatalf("expected S: %v to be high", highS.S)
+	}
+
+	// Both forms are valid ECDSA signatures.
+	if !Verify(curve, k.PublicKey, hash, highS) {
+		t.Fatalf("failed to verify the high S signature")
+	}
+
+	if !highS.NormalizeS(curve) {
+		t.Fatalf("should have normalized a high S")
+	}
+
+	if highS.S.Cmp(sig.S) != 0 {
+		t.Fatalf("expected normalized S: %v, received: %v", sig.S, highS.S)
+	}
 }
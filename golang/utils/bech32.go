@@ -0,0 +1,218 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// bech32Const is the checksum constant of BIP 173.
+	bech32Const = 1
+
+	// bech32mConst is the checksum constant of BIP 350.
+	bech32mConst = 0x2bc830a3
+)
+
+// bech32Polymod computes the BCH checksum over the 5-bit values.
+func bech32Polymod(values []byte) uint32 {
+	gen := []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+
+	return chk
+}
+
+// bech32HrpExpand expands the human readable part for use in the checksum.
+func bech32HrpExpand(hrp string) []byte {
+	result := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		result = append(result, hrp[i]>>5)
+	}
+	result = append(result, 0)
+	for i := 0; i < len(hrp); i++ {
+		result = append(result, hrp[i]&31)
+	}
+
+	return result
+}
+
+// bech32CreateChecksum will create the 6 value checksum for the hrp and
+// data using the checksum constant.
+func bech32CreateChecksum(hrp string, data []byte, constant uint32) []byte {
+	values := append(bech32HrpExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	polymod := bech32Polymod(values) ^ constant
+
+	checksum := make([]byte, 6)
+	for i := range checksum {
+		checksum[i] = byte((polymod >> uint(5*(5-i))) & 31)
+	}
+
+	return checksum
+}
+
+// encodeBech32 will encode the hrp and 5-bit data with the checksum constant.
+func encodeBech32(hrp string, data []byte, constant uint32) string {
+	combined := append(append([]byte{}, data...), bech32CreateChecksum(hrp, data, constant)...)
+
+	var result strings.Builder
+	result.WriteString(hrp)
+	result.WriteString("1")
+	for _, v := range combined {
+		result.WriteByte(bech32Charset[v])
+	}
+
+	return result.String()
+}
+
+// decodeBech32 will decode a bech32 or bech32m string, returning the hrp, the
+// 5-bit data without the checksum and the checksum constant it matched.
+func decodeBech32(s string) (string, []byte, uint32, error) {
+	if len(s) > 90 {
+		return "", nil, 0, errors.New("bech32 string is too long")
+	}
+
+	// Mixed case strings are not allowed.
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, 0, errors.New("bech32 string is mixed case")
+	}
+	s = strings.ToLower(s)
+
+	pos := strings.LastIndex(s, "1")
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, 0, errors.New("invalid bech32 separator position")
+	}
+
+	hrp := s[:pos]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, errors.New("invalid bech32 human readable part")
+		}
+	}
+
+	data := make([]byte, 0, len(s)-pos-1)
+	for i := pos + 1; i < len(s); i++ {
+		v := strings.IndexByte(bech32Charset, s[i])
+		if v == -1 {
+			return "", nil, 0, errors.New("invalid bech32 character")
+		}
+		data = append(data, byte(v))
+	}
+
+	constant := bech32Polymod(append(bech32HrpExpand(hrp), data...))
+	if constant != bech32Const && constant != bech32mConst {
+		return "", nil, 0, errors.New("invalid bech32 checksum")
+	}
+
+	return hrp, data[:len(data)-6], constant, nil
+}
+
+// ConvertBits will regroup a slice of fromBits-bit values into toBits-bit
+// values, padding the final group with zeroes if pad is true.
+func ConvertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<toBits - 1
+
+	var result []byte
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, errors.New("invalid data range for bit conversion")
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(acc>>bits&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding for bit conversion")
+	}
+
+	return result, nil
+}
+
+// EncodeSegwitAddress will encode a witness version and program as a segwit
+// address with the human readable part hrp ("bc" for mainnet and "tb" for
+// testnet). Version 0 uses bech32 and later versions use bech32m.
+func EncodeSegwitAddress(hrp string, version byte, program []byte) (string, error) {
+	if version > 16 {
+		return "", errors.New("invalid witness version")
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return "", errors.New("invalid witness program length")
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return "", errors.New("invalid witness program length for version 0")
+	}
+
+	data, err := ConvertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	constant := uint32(bech32Const)
+	if version > 0 {
+		constant = bech32mConst
+	}
+
+	return encodeBech32(hrp, append([]byte{version}, data...), constant), nil
+}
+
+// DecodeSegwitAddress will decode a segwit address, checking it has the human
+// readable part hrp, and return the witness version and program.
+func DecodeSegwitAddress(hrp, address string) (byte, []byte, error) {
+	decodedHrp, data, constant, err := decodeBech32(address)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if decodedHrp != hrp {
+		return 0, nil, errors.New("segwit address has the wrong human readable part")
+	}
+
+	if len(data) < 1 {
+		return 0, nil, errors.New("segwit address has no witness version")
+	}
+
+	version := data[0]
+	if version > 16 {
+		return 0, nil, errors.New("invalid witness version")
+	}
+
+	// Version 0 must use bech32 and later versions must use bech32m.
+	if (version == 0 && constant != bech32Const) || (version != 0 && constant != bech32mConst) {
+		return 0, nil, errors.New("segwit address uses the wrong checksum for its version")
+	}
+
+	program, err := ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(program) < 2 || len(program) > 40 {
+		return 0, nil, errors.New("invalid witness program length")
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return 0, nil, errors.New("invalid witness program length for version 0")
+	}
+
+	return version, program, nil
+}
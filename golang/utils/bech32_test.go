@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// TestSegwitAddress will test that we can encode and decode the segwit
+// addresses from the BIP 173 and BIP 350 test vectors.
+func TestSegwitAddress(t *testing.T) {
+	tests := []struct {
+		hrp     string
+		address string
+		version byte
+		program string
+	}{
+		{"bc", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", 0, "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb", "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", 0, "1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", 1, "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+
+	for _, test := range tests {
+		program, _ := hex.DecodeString(test.program)
+
+		address, err := EncodeSegwitAddress(test.hrp, test.version, program)
+		if err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+		if address != test.address {
+			t.Fatalf("expected: %v, received: %v", test.address, address)
+		}
+
+		version, decoded, err := DecodeSegwitAddress(test.hrp, test.address)
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		if version != test.version || hex.EncodeToString(decoded) != test.program {
+			t.Fatalf("expected: (%v, %v), received: (%v, %x)", test.version, test.program, version, decoded)
+		}
+	}
+}
+
+// TestSegwitAddressInvalid will test that invalid segwit addresses from the
+// BIP 173 and BIP 350 test vectors are rejected.
+func TestSegwitAddressInvalid(t *testing.T) {
+	tests := []struct {
+		hrp     string
+		address string
+	}{
+		// Invalid checksum.
+		{"bc", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5"},
+		// Version 0 with a bech32m checksum.
+		{"bc", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh"},
+		// Version 1 with a bech32 checksum.
+		{"bc", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd"},
+		// Mixed case.
+		{"tb", "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sL5k7"},
+		// Wrong human readable part.
+		{"tb", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+	}
+
+	for _, test := range tests {
+		if _, _, err := DecodeSegwitAddress(test.hrp, test.address); err == nil {
+			t.Fatalf("should have rejected address: %v", test.address)
+		}
+	}
+}
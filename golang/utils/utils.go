@@ -129,6 +129,38 @@ func DecodeBase58(s string) ([]byte, error) {
 	return output, nil
 }
 
+// EncodeBase58Check will append the first four bytes of the double SHA256 of
+// b as a checksum and Base58 encode the result.
+func EncodeBase58Check(b []byte) string {
+	checksum := DoubleSHA256(b)[:4]
+
+	raw := make([]byte, 0, len(b)+4)
+	raw = append(raw, b...)
+	raw = append(raw, checksum...)
+
+	return EncodeBase58(raw)
+}
+
+// DecodeBase58Check will decode a Base58 string, verify its four byte
+// checksum and return the payload without the checksum.
+func DecodeBase58Check(s string) ([]byte, error) {
+	raw, err := DecodeBase58(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(raw) < 4 {
+		return nil, errors.New("base58check string is too short")
+	}
+
+	payload, checksum := raw[:len(raw)-4], raw[len(raw)-4:]
+	if !bytes.Equal(DoubleSHA256(payload)[:4], checksum) {
+		return nil, errors.New("invalid base58check checksum")
+	}
+
+	return payload, nil
+}
+
 // ConvStrBigInt will take a string representation of a large number and
 // convert it to a *big.Int.
 func ConvStrBigInt(n string) (*big.Int, error) {
@@ -159,6 +191,21 @@ func DoubleSHA256(b []byte) []byte {
 	return generateHash(generateHash(b, sha256.New()), sha256.New())
 }
 
+// TaggedHash generates the BIP 340 tagged hash
+// sha256(sha256(tag) || sha256(tag) || msgs...).
+func TaggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	hasher := sha256.New()
+	hasher.Write(tagHash[:])
+	hasher.Write(tagHash[:])
+	for _, msg := range msgs {
+		hasher.Write(msg)
+	}
+
+	return hasher.Sum(nil)
+}
+
 // ConvIntStrToBigInt will convert the string representations of the int string
 // to a big int.
 func ConvIntStrToBigInt(s string) (*big.Int, error) {
@@ -215,40 +262,35 @@ func ReadVarint(stream *bytes.Buffer) int {
 	return int(varint)
 }
 
-// EncodeVarint will take an int and encode it as a 1 byte varint.
+// EncodeVarint will take an int and encode it as a varint of 1, 3, 5 or 9
+// bytes.
 func EncodeVarint(n int) ([]byte, error) {
-	var varint []byte
-	if n < 0xfd {
-		varint = make([]byte, 8)
-		binary.LittleEndian.PutUint32(varint, uint32(n))
-		// TODO: Think of a way to consistenly us this as a function.
-		// Removes trailing null characters.
-		varint = bytes.Trim(varint, "\x00")
-		return varint, nil
+	if n < 0 {
+		return nil, errors.New("failed to encode varint")
+	}
 
-	} else if n < 0x10000 {
-		varint = make([]byte, 8)
-		varint = append(varint, 0xfd)
-		binary.LittleEndian.PutUint32(varint, uint32(n))
-		varint = bytes.Trim(varint, "\x00")
-		return varint, nil
+	switch {
+	case n < 0xfd:
+		return []byte{byte(n)}, nil
 
-	} else if n < 0x100000000 {
-		varint = make([]byte, 8)
-		varint = append(varint, 0xfe)
-		binary.LittleEndian.PutUint32(varint, uint32(n))
-		varint = bytes.Trim(varint, "\x00")
+	case n <= 0xffff:
+		varint := make([]byte, 3)
+		varint[0] = 0xfd
+		binary.LittleEndian.PutUint16(varint[1:], uint16(n))
 		return varint, nil
 
-		// TODO: Need to fix this overflow with n.
-		// } else if n < 0x10000000000000000 {
-		// varint = make([]byte, 8)
-		// varint = append(varint, 0xff)
-		// binary.LittleEndian.PutUint32(varint, uint32(n))
-		// return varint, nil
+	case uint64(n) <= 0xffffffff:
+		varint := make([]byte, 5)
+		varint[0] = 0xfe
+		binary.LittleEndian.PutUint32(varint[1:], uint32(n))
+		return varint, nil
 	}
 
-	return nil, errors.New("failed to encode varint")
+	varint := make([]byte, 9)
+	varint[0] = 0xff
+	binary.LittleEndian.PutUint64(varint[1:], uint64(n))
+
+	return varint, nil
 }
 
 // convHexStrToBigInt will convert the constants of the s, that are in
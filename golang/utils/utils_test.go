@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"testing"
 )
@@ -54,3 +55,72 @@ func TestDecodeBase58(t *testing.T) {
 			"expected: %v received: %v", expected, resultHex)
 	}
 }
+
+// TestBase58Check will test that a payload round trips with its checksum and
+// that a corrupted checksum is rejected.
+func TestBase58Check(t *testing.T) {
+	address := "mo24iC138ffpdWiFsH8y7dq6v5CDD1UbiT"
+
+	payload, err := DecodeBase58Check(address)
+	if err != nil {
+		t.Fatalf("failed to decode a base58check address: %v", err)
+	}
+	if resultHex := fmt.Sprintf("%x", payload); resultHex != "6f524a4c9f658b9e482c40669096d93f2a6d96de52" {
+		t.Fatalf("unexpected payload: %v", resultHex)
+	}
+
+	if encoded := EncodeBase58Check(payload); encoded != address {
+		t.Fatalf("expected: %v, received: %v", address, encoded)
+	}
+
+	// Changing the last character changes the checksum.
+	if _, err := DecodeBase58Check(address[:len(address)-1] + "U"); err == nil {
+		t.Fatalf("should have rejected an invalid checksum")
+	}
+
+	if _, err := DecodeBase58Check("111"); err == nil {
+		t.Fatalf("should have rejected a string shorter than the checksum")
+	}
+}
+
+// TestEncodeVarint will test that we encode each varint size correctly.
+func TestEncodeVarint(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{0, "00"},
+		{0x6a, "6a"},
+		{0xfc, "fc"},
+		{0xfd, "fdfd00"},
+		{0x1234, "fd3412"},
+		{0x10000, "fe00000100"},
+		{0x100000000, "ff0000000001000000"},
+	}
+
+	for _, test := range tests {
+		varint, err := EncodeVarint(test.n)
+		if err != nil {
+			t.Fatalf("failed to encode varint: %v", err)
+		}
+
+		if fmt.Sprintf("%x", varint) != test.expected {
+			t.Fatalf("n: %v, expected: %v, received: %x", test.n, test.expected, varint)
+		}
+	}
+}
+
+// TestTaggedHash will test that a tagged hash is the same as hashing the
+// doubled tag hash with the message.
+func TestTaggedHash(t *testing.T) {
+	tagHash := sha256.Sum256([]byte("BIP0340/challenge"))
+	msg := []byte("message")
+
+	expected := sha256.Sum256(append(append(tagHash[:], tagHash[:]...), msg...))
+
+	// Passing the message in parts should give the same hash.
+	result := TaggedHash("BIP0340/challenge", msg[:3], msg[3:])
+	if fmt.Sprintf("%x", result) != fmt.Sprintf("%x", expected) {
+		t.Fatalf("expected: %x, received: %x", expected, result)
+	}
+}